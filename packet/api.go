@@ -0,0 +1,355 @@
+package packet
+
+import (
+	"fmt"
+
+	"github.com/packethost/packngo"
+)
+
+// This file holds all the requests which go to the Packet API directly
+// instead of through packngo, because packngo lacks the endpoint, or doesn't
+// decode or send some fields. The rest of the provider should use packngo.
+// Errors are passed through friendlyError.
+
+// apiRequest sends a request to the Packet API and decodes the response into
+// v.
+func apiRequest(client *packngo.Client, method, path string, body, v interface{}) (*packngo.Response, error) {
+	resp, err := client.DoRequest(method, path, body, v)
+	return resp, friendlyError(err)
+}
+
+// listMeta is the pagination part of list responses. The API returns the
+// first page only unless the next ones are requested.
+type listMeta struct {
+	Next *packngo.Href `json:"next,omitempty"`
+}
+
+// nextPage returns the path of the next page with params added, as packngo
+// does, or "" on the last page.
+func (m listMeta) nextPage(params string) string {
+	if m.Next == nil {
+		return ""
+	}
+	if params == "" {
+		return m.Next.Href
+	}
+	return fmt.Sprintf("%s&%s", m.Next.Href, params)
+}
+
+func getDevice(client *packngo.Client, deviceID string) (*packetDevice, error) {
+	device := new(packetDevice)
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/devices/%s?include=facility", deviceID), nil, device)
+	if err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// createDevice is used instead of client.Devices.Create when the device
+// needs fields which packngo.DeviceCreateRequest doesn't have.
+func createDevice(client *packngo.Client, createRequest *deviceCreateRequest) (*packngo.Device, error) {
+	device := new(packngo.Device)
+	_, err := apiRequest(client, "POST", fmt.Sprintf("/projects/%s/devices", createRequest.ProjectID), createRequest, device)
+	if err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// updateDevice sends only the given fields of the device. It's used instead
+// of client.Devices.Update, which sends customdata as a string instead of a
+// JSON object.
+func updateDevice(client *packngo.Client, deviceID string, fields map[string]interface{}) error {
+	_, err := apiRequest(client, "PUT", fmt.Sprintf("/devices/%s", deviceID), fields, nil)
+	return err
+}
+
+func deviceAction(client *packngo.Client, deviceID string, action map[string]interface{}) error {
+	_, err := apiRequest(client, "POST", fmt.Sprintf("/devices/%s/actions", deviceID), action, nil)
+	return err
+}
+
+// convertPortToLayer3 is used instead of client.DevicePorts.PortToLayerThree,
+// which always requests public addresses.
+func convertPortToLayer3(client *packngo.Client, portID string, ipAddresses []deviceIPAddress) error {
+	req := struct {
+		RequestIPs []deviceIPAddress `json:"request_ips"`
+	}{
+		RequestIPs: ipAddresses,
+	}
+	_, err := apiRequest(client, "POST", fmt.Sprintf("/ports/%s/convert/layer-3", portID), &req, nil)
+	return err
+}
+
+func setPortNativeVlan(client *packngo.Client, portID, vlanID string) error {
+	_, err := apiRequest(client, "POST", fmt.Sprintf("/ports/%s/native-vlan", portID), map[string]string{"vnid": vlanID}, nil)
+	return err
+}
+
+func removePortNativeVlan(client *packngo.Client, portID string) error {
+	_, err := apiRequest(client, "DELETE", fmt.Sprintf("/ports/%s/native-vlan", portID), nil, nil)
+	return err
+}
+
+func getVirtualNetwork(client *packngo.Client, vlanID string) (*virtualNetwork, error) {
+	vlan := new(virtualNetwork)
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/virtual-networks/%s?include=assigned_to", vlanID), nil, vlan)
+	if err != nil {
+		return nil, err
+	}
+	return vlan, nil
+}
+
+func getVolumeWithAccess(client *packngo.Client, volumeID string) (*volumeWithAccess, error) {
+	volume := new(volumeWithAccess)
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/storage/%s", volumeID), nil, volume)
+	if err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+func getIPReservation(client *packngo.Client, reservationID string) (*packetIPReservation, error) {
+	reservation := new(packetIPReservation)
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/ips/%s", reservationID), nil, reservation)
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// listIPReservations includes the assignments, so that the devices they are
+// assigned to don't need to be read one by one.
+func listIPReservations(client *packngo.Client, projectID string) ([]packetIPReservation, error) {
+	var reservations []packetIPReservation
+	params := "include=assignments"
+	path := fmt.Sprintf("/projects/%s/ips?%s", projectID, params)
+	for path != "" {
+		root := struct {
+			Reservations []packetIPReservation `json:"ip_addresses"`
+			Meta         listMeta              `json:"meta"`
+		}{}
+		_, err := apiRequest(client, "GET", path, nil, &root)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, root.Reservations...)
+		path = root.Meta.nextPage(params)
+	}
+	return reservations, nil
+}
+
+// requestGlobalIPReservation is used instead of client.ProjectIPs.Request,
+// because packngo.IPReservationRequest always sends the facility, which the
+// API rejects for global blocks.
+func requestGlobalIPReservation(client *packngo.Client, projectID string, req *globalIPReservationRequest) (*packngo.IPAddressReservation, error) {
+	reservation := new(packngo.IPAddressReservation)
+	_, err := apiRequest(client, "POST", fmt.Sprintf("/projects/%s/ips", projectID), req, reservation)
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// getPaymentMethod is needed because packngo.Client has no payment methods
+// service, only the list of the organization's payment methods.
+func getPaymentMethod(client *packngo.Client, id string) (*paymentMethod, error) {
+	pm := new(paymentMethod)
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/payment-methods/%s", id), nil, pm)
+	if err != nil {
+		return nil, err
+	}
+	return pm, nil
+}
+
+func listPaymentMethods(client *packngo.Client, orgID string) ([]paymentMethod, error) {
+	var paymentMethods []paymentMethod
+	path := fmt.Sprintf("/organizations/%s/payment-methods", orgID)
+	for path != "" {
+		root := struct {
+			PaymentMethods []paymentMethod `json:"payment_methods"`
+			Meta           listMeta        `json:"meta"`
+		}{}
+		_, err := apiRequest(client, "GET", path, nil, &root)
+		if err != nil {
+			return nil, err
+		}
+		paymentMethods = append(paymentMethods, root.PaymentMethods...)
+		path = root.Meta.nextPage("")
+	}
+	return paymentMethods, nil
+}
+
+func listProjectMemberships(client *packngo.Client, projectID string) ([]projectMembership, error) {
+	var memberships []projectMembership
+	params := "include=user"
+	path := fmt.Sprintf("/projects/%s/memberships?%s", projectID, params)
+	for path != "" {
+		root := struct {
+			Memberships []projectMembership `json:"memberships"`
+			Meta        listMeta            `json:"meta"`
+		}{}
+		_, err := apiRequest(client, "GET", path, nil, &root)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, root.Memberships...)
+		path = root.Meta.nextPage(params)
+	}
+	return memberships, nil
+}
+
+func createProjectMembership(client *packngo.Client, projectID string, req *projectMembershipRequest) (*projectMembership, error) {
+	membership := new(projectMembership)
+	_, err := apiRequest(client, "POST", fmt.Sprintf("/projects/%s/memberships", projectID), req, membership)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func getProjectMembership(client *packngo.Client, membershipID string) (*projectMembership, error) {
+	membership := new(projectMembership)
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/memberships/%s?include=user", membershipID), nil, membership)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func updateProjectMembership(client *packngo.Client, membershipID string, req *projectMembershipRequest) error {
+	_, err := apiRequest(client, "PUT", fmt.Sprintf("/memberships/%s", membershipID), req, nil)
+	return err
+}
+
+func deleteProjectMembership(client *packngo.Client, membershipID string) error {
+	_, err := apiRequest(client, "DELETE", fmt.Sprintf("/memberships/%s", membershipID), nil, nil)
+	return err
+}
+
+func createProjectInvitation(client *packngo.Client, projectID string, req *projectInvitationRequest) (*projectInvitation, error) {
+	invitation := new(projectInvitation)
+	_, err := apiRequest(client, "POST", fmt.Sprintf("/projects/%s/invitations", projectID), req, invitation)
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func getProjectInvitation(client *packngo.Client, invitationID string) (*projectInvitation, error) {
+	invitation := new(projectInvitation)
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/invitations/%s", invitationID), nil, invitation)
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func deleteProjectInvitation(client *packngo.Client, invitationID string) error {
+	_, err := apiRequest(client, "DELETE", fmt.Sprintf("/invitations/%s", invitationID), nil, nil)
+	return err
+}
+
+func createBGPConfig(client *packngo.Client, projectID string, c *bgpConfig) error {
+	_, err := apiRequest(client, "POST", fmt.Sprintf("/projects/%s/bgp-configs", projectID), c, nil)
+	return err
+}
+
+// getBGPConfig returns nil if BGP is not enabled in the project.
+func getBGPConfig(client *packngo.Client, projectID string) (*bgpConfig, error) {
+	c := new(bgpConfig)
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/projects/%s/bgp-config", projectID), nil, c)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if c.ID == "" && c.DeploymentType == "" {
+		return nil, nil
+	}
+	return c, nil
+}
+
+func createBGPSession(client *packngo.Client, deviceID string, req *bgpSession) (*bgpSession, error) {
+	session := new(bgpSession)
+	_, err := apiRequest(client, "POST", fmt.Sprintf("/devices/%s/bgp/sessions", deviceID), req, session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func getBGPSession(client *packngo.Client, sessionID string) (*bgpSession, error) {
+	session := new(bgpSession)
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/bgp/sessions/%s", sessionID), nil, session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func deleteBGPSession(client *packngo.Client, sessionID string) error {
+	_, err := apiRequest(client, "DELETE", fmt.Sprintf("/bgp/sessions/%s", sessionID), nil, nil)
+	return err
+}
+
+func listBGPNeighbors(client *packngo.Client, deviceID string) ([]bgpNeighbor, error) {
+	root := struct {
+		BGPNeighbors []bgpNeighbor `json:"bgp_neighbors"`
+	}{}
+	_, err := apiRequest(client, "GET", fmt.Sprintf("/devices/%s/bgp/neighbors", deviceID), nil, &root)
+	if err != nil {
+		return nil, err
+	}
+	return root.BGPNeighbors, nil
+}
+
+func listHardwareReservations(client *packngo.Client, projectID string) ([]hardwareReservation, error) {
+	var reservations []hardwareReservation
+	params := "include=facility,plan,device"
+	path := fmt.Sprintf("/projects/%s/hardware-reservations?%s", projectID, params)
+	for path != "" {
+		root := struct {
+			HardwareReservations []hardwareReservation `json:"hardware_reservations"`
+			Meta                 listMeta              `json:"meta"`
+		}{}
+		_, err := apiRequest(client, "GET", path, nil, &root)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, root.HardwareReservations...)
+		path = root.Meta.nextPage(params)
+	}
+	return reservations, nil
+}
+
+func checkCapacity(client *packngo.Client, facility, plan string, quantity int) (bool, error) {
+	req := struct {
+		Servers []capacityServer `json:"servers"`
+	}{
+		Servers: []capacityServer{{Facility: facility, Plan: plan, Quantity: quantity}},
+	}
+	resp := struct {
+		Servers []capacityServer `json:"servers"`
+	}{}
+	_, err := apiRequest(client, "POST", "/capacity", &req, &resp)
+	if err != nil {
+		return false, err
+	}
+	if len(resp.Servers) == 0 {
+		return false, fmt.Errorf("Capacity check for plan %s in facility %s returned no result", plan, facility)
+	}
+	return resp.Servers[0].Available, nil
+}
+
+func getCapacityReport(client *packngo.Client) (capacityReport, error) {
+	root := struct {
+		Capacity capacityReport `json:"capacity"`
+	}{}
+	_, err := apiRequest(client, "GET", "/capacity", nil, &root)
+	if err != nil {
+		return nil, err
+	}
+	return root.Capacity, nil
+}
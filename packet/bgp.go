@@ -0,0 +1,92 @@
+package packet
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var bgpDeploymentTypes = []string{"local", "global"}
+
+// bgpConfig is the BGP configuration of a project.
+type bgpConfig struct {
+	ID             string `json:"id,omitempty"`
+	Status         string `json:"status,omitempty"`
+	DeploymentType string `json:"deployment_type,omitempty"`
+	ASN            int    `json:"asn,omitempty"`
+	MD5            string `json:"md5,omitempty"`
+	MaxPrefix      int    `json:"max_prefix,omitempty"`
+}
+
+// bgpConfigSchema is computed, because BGP can be enabled in the project
+// outside of Terraform, and can't be disabled.
+func bgpConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"deployment_type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: stringInValues(bgpDeploymentTypes),
+				},
+				"asn": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+				"md5": {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+				},
+				"max_prefix": {
+					Type:     schema.TypeInt,
+					Optional: true,
+					Computed: true,
+				},
+				"status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func expandBGPConfig(d *schema.ResourceData) *bgpConfig {
+	list := d.Get("bgp_config").([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	m := list[0].(map[string]interface{})
+	return &bgpConfig{
+		DeploymentType: m["deployment_type"].(string),
+		ASN:            m["asn"].(int),
+		MD5:            m["md5"].(string),
+		MaxPrefix:      m["max_prefix"].(int),
+	}
+}
+
+// flattenBGPConfig converts the config for the state. The API doesn't always
+// return the MD5 password, so the configured one is kept in that case.
+func flattenBGPConfig(d *schema.ResourceData, c *bgpConfig) []map[string]interface{} {
+	if c == nil {
+		return []map[string]interface{}{}
+	}
+
+	md5 := c.MD5
+	if md5 == "" {
+		if current := expandBGPConfig(d); current != nil {
+			md5 = current.MD5
+		}
+	}
+
+	return []map[string]interface{}{{
+		"deployment_type": c.DeploymentType,
+		"asn":             c.ASN,
+		"md5":             md5,
+		"max_prefix":      c.MaxPrefix,
+		"status":          c.Status,
+	}}
+}
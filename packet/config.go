@@ -19,5 +19,5 @@ type Config struct {
 func (c *Config) Client() *packngo.Client {
 	client := cleanhttp.DefaultClient()
 	client.Transport = logging.NewTransport("Packet", client.Transport)
-	return packngo.NewClient(consumerToken, c.AuthToken, client)
+	return packngo.NewClientWithAuth(consumerToken, c.AuthToken, client)
 }
@@ -0,0 +1,130 @@
+package packet
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+// capacityServer is a facility, plan and quantity triple for the capacity
+// check endpoint.
+type capacityServer struct {
+	Facility  string `json:"facility"`
+	Plan      string `json:"plan"`
+	Quantity  int    `json:"quantity"`
+	Available bool   `json:"available,omitempty"`
+}
+
+// capacityReport maps facility codes to plan slugs to capacity levels, e.g.
+// "normal", "limited" or "unavailable".
+type capacityReport map[string]map[string]struct {
+	Level string `json:"level"`
+}
+
+// facilitiesWithCapacity returns sorted codes of facilities where the plan is
+// not out of stock.
+func (r capacityReport) facilitiesWithCapacity(plan string) []string {
+	facilities := []string{}
+	for facility, plans := range r {
+		if c, ok := plans[plan]; ok && c.Level != "unavailable" {
+			facilities = append(facilities, facility)
+		}
+	}
+	sort.Strings(facilities)
+	return facilities
+}
+
+// checkDeviceCapacity fails if the facility doesn't have enough devices of
+// the plan in stock, listing facilities which do. If the capacity can't be
+// checked, it's only logged, and the API has the final say.
+func checkDeviceCapacity(client *packngo.Client, facility, plan string, quantity int) error {
+	available, err := checkCapacity(client, facility, plan, quantity)
+	if err != nil {
+		log.Printf("[WARN] Failed to check capacity of plan %s in facility %s: %s", plan, facility, err)
+		return nil
+	}
+	if available {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Facility %s doesn't have capacity for %d device(s) of plan %s", facility, quantity, plan)
+	report, err := getCapacityReport(client)
+	if err != nil {
+		log.Printf("[WARN] Failed to get capacity report: %s", err)
+		return fmt.Errorf("%s", msg)
+	}
+	if others := report.facilitiesWithCapacity(plan); len(others) > 0 {
+		msg += fmt.Sprintf("; facilities with capacity: %s", strings.Join(others, ", "))
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+func dataSourcePacketCapacity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePacketCapacityRead,
+		Schema: map[string]*schema.Schema{
+			"plan": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"facility": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"quantity": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+			"available": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"level": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"facilities": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourcePacketCapacityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	plan := d.Get("plan").(string)
+	facility := d.Get("facility").(string)
+	quantity := d.Get("quantity").(int)
+
+	log.Println("[DEBUG] packet_capacity - getting capacity report")
+	report, err := getCapacityReport(client)
+	if err != nil {
+		return err
+	}
+	d.Set("facilities", report.facilitiesWithCapacity(plan))
+
+	if facility == "" {
+		d.SetId(plan)
+		d.Set("available", len(report.facilitiesWithCapacity(plan)) > 0)
+		d.Set("level", "")
+		return nil
+	}
+
+	available, err := checkCapacity(client, facility, plan, quantity)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s-%s-%d", facility, plan, quantity))
+	d.Set("available", available)
+	d.Set("level", report[facility][plan].Level)
+
+	return nil
+}
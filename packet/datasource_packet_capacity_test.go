@@ -0,0 +1,59 @@
+package packet
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccPacketCapacityBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testCapacityConfigBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.packet_capacity.test", "available"),
+					resource.TestCheckResourceAttrSet(
+						"data.packet_capacity.test", "level"),
+					resource.TestCheckResourceAttrSet(
+						"data.packet_capacity.test", "facilities.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestCapacityReportFacilitiesWithCapacity(t *testing.T) {
+	var report capacityReport
+	err := json.Unmarshal([]byte(`{
+		"sjc1": {"baremetal_0": {"level": "limited"}, "baremetal_1": {"level": "unavailable"}},
+		"ams1": {"baremetal_0": {"level": "normal"}},
+		"ewr1": {"baremetal_0": {"level": "unavailable"}, "baremetal_1": {"level": "normal"}}
+	}`), &report)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	facilities := report.facilitiesWithCapacity("baremetal_0")
+	if !reflect.DeepEqual(facilities, []string{"ams1", "sjc1"}) {
+		t.Fatalf("unexpected facilities %v", facilities)
+	}
+
+	facilities = report.facilitiesWithCapacity("baremetal_2")
+	if len(facilities) != 0 {
+		t.Fatalf("expected no facilities, got %v", facilities)
+	}
+}
+
+const testCapacityConfigBasic = `
+data "packet_capacity" "test" {
+    plan     = "baremetal_0"
+    facility = "ewr1"
+    quantity = 2
+}
+`
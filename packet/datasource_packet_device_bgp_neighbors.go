@@ -0,0 +1,145 @@
+package packet
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+type bgpRoute struct {
+	Route string `json:"route"`
+	Exact bool   `json:"exact"`
+}
+
+// bgpNeighbor describes the peering of a device with the Packet routers.
+type bgpNeighbor struct {
+	AddressFamily int        `json:"address_family"`
+	CustomerAs    int        `json:"customer_as"`
+	CustomerIP    string     `json:"customer_ip"`
+	MD5Enabled    bool       `json:"md5_enabled"`
+	MD5Password   string     `json:"md5_password"`
+	Multihop      bool       `json:"multihop"`
+	PeerAs        int        `json:"peer_as"`
+	PeerIps       []string   `json:"peer_ips"`
+	RoutesIn      []bgpRoute `json:"routes_in"`
+	RoutesOut     []bgpRoute `json:"routes_out"`
+}
+
+func bgpRouteSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"route": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"exact": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePacketDeviceBGPNeighbors() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePacketDeviceBGPNeighborsRead,
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"bgp_neighbors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address_family": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"customer_as": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"customer_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"md5_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"md5_password": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"multihop": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"peer_as": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"peer_ips": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"routes_in":  bgpRouteSchema(),
+						"routes_out": bgpRouteSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenBGPRoutes(routes []bgpRoute) []map[string]interface{} {
+	ret := make([]map[string]interface{}, 0, len(routes))
+	for _, r := range routes {
+		ret = append(ret, map[string]interface{}{
+			"route": r.Route,
+			"exact": r.Exact,
+		})
+	}
+	return ret
+}
+
+func dataSourcePacketDeviceBGPNeighborsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	deviceID := d.Get("device_id").(string)
+
+	log.Println("[DEBUG] packet_device_bgp_neighbors - getting BGP neighbors of a device")
+	bgpNeighbors, err := listBGPNeighbors(client, deviceID)
+	if err != nil {
+		return err
+	}
+
+	neighbors := make([]map[string]interface{}, 0, len(bgpNeighbors))
+	for _, n := range bgpNeighbors {
+		neighbors = append(neighbors, map[string]interface{}{
+			"address_family": n.AddressFamily,
+			"customer_as":    n.CustomerAs,
+			"customer_ip":    n.CustomerIP,
+			"md5_enabled":    n.MD5Enabled,
+			"md5_password":   n.MD5Password,
+			"multihop":       n.Multihop,
+			"peer_as":        n.PeerAs,
+			"peer_ips":       n.PeerIps,
+			"routes_in":      flattenBGPRoutes(n.RoutesIn),
+			"routes_out":     flattenBGPRoutes(n.RoutesOut),
+		})
+	}
+
+	d.SetId(deviceID)
+	d.Set("bgp_neighbors", neighbors)
+
+	return nil
+}
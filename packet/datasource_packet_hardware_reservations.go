@@ -0,0 +1,239 @@
+package packet
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+// hardwareReservation is a piece of hardware reserved for a project.
+type hardwareReservation struct {
+	ID            string            `json:"id"`
+	ShortID       string            `json:"short_id,omitempty"`
+	Provisionable bool              `json:"provisionable"`
+	Spare         bool              `json:"spare"`
+	Facility      *packngo.Facility `json:"facility,omitempty"`
+	Plan          *packngo.Plan     `json:"plan,omitempty"`
+	Device        *packngo.Device   `json:"device,omitempty"`
+	Created       string            `json:"created_at,omitempty"`
+	Href          string            `json:"href,omitempty"`
+}
+
+func (r *hardwareReservation) facilityCode() string {
+	if r.Facility == nil {
+		return ""
+	}
+	return r.Facility.Code
+}
+
+func (r *hardwareReservation) planSlug() string {
+	if r.Plan == nil {
+		return ""
+	}
+	return r.Plan.Slug
+}
+
+func (r *hardwareReservation) deviceID() string {
+	if r.Device == nil {
+		return ""
+	}
+	if r.Device.ID != "" {
+		return r.Device.ID
+	}
+	return path.Base(r.Device.Href)
+}
+
+// free tells whether a device can be deployed to the reservation.
+func (r *hardwareReservation) free() bool {
+	return r.Provisionable && r.deviceID() == ""
+}
+
+// checkHardwareReservation verifies that count devices of the plan can be
+// deployed to the reservation, or to free reservations in the facility if
+// reservationID is "next-available". Without this check, the API fails late
+// and with an unclear error.
+func checkHardwareReservation(client *packngo.Client, projectID, reservationID, plan, facility string, count int) error {
+	reservations, err := listHardwareReservations(client, projectID)
+	if err != nil {
+		return err
+	}
+
+	if reservationID != "next-available" {
+		if count > 1 {
+			return fmt.Errorf("Hardware reservation %s is used by %d planned devices", reservationID, count)
+		}
+		for _, r := range reservations {
+			if r.ID != reservationID {
+				continue
+			}
+			if r.deviceID() != "" {
+				return fmt.Errorf("Hardware reservation %s is already used by device %s", r.ID, r.deviceID())
+			}
+			if !r.Provisionable {
+				return fmt.Errorf("Hardware reservation %s is not provisionable", r.ID)
+			}
+			if r.planSlug() != plan || r.facilityCode() != facility {
+				return fmt.Errorf("Hardware reservation %s is for plan %s in facility %s, not %s in %s",
+					r.ID, r.planSlug(), r.facilityCode(), plan, facility)
+			}
+			return nil
+		}
+		return fmt.Errorf("Hardware reservation %s was not found in project %s", reservationID, projectID)
+	}
+
+	free := 0
+	elsewhere := []string{}
+	for _, r := range reservations {
+		if !r.free() || r.planSlug() != plan {
+			continue
+		}
+		if r.facilityCode() == facility {
+			free++
+			continue
+		}
+		if !stringInSlice(r.facilityCode(), elsewhere) {
+			elsewhere = append(elsewhere, r.facilityCode())
+		}
+	}
+	if free >= count {
+		return nil
+	}
+
+	msg := fmt.Sprintf("There is no free hardware reservation of plan %s in facility %s in project %s", plan, facility, projectID)
+	if free > 0 {
+		msg = fmt.Sprintf("There are %d free hardware reservations of plan %s in facility %s in project %s for %d planned devices",
+			free, plan, facility, projectID, count)
+	}
+	if len(elsewhere) > 0 {
+		msg += fmt.Sprintf("; free reservations of the plan are in: %s", strings.Join(elsewhere, ", "))
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+func dataSourcePacketHardwareReservations() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePacketHardwareReservationsRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"plan": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"facility": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"provisionable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"free": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"reservations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"short_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"plan": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"facility": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"provisionable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"spare": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"device_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePacketHardwareReservationsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	projectID := d.Get("project_id").(string)
+	log.Println("[DEBUG] packet_hardware_reservations - getting list of hardware reservations in a project")
+	reservations, err := listHardwareReservations(client, projectID)
+	if err != nil {
+		return err
+	}
+
+	plan := d.Get("plan").(string)
+	facility := d.Get("facility").(string)
+	provisionable, provisionableSet := d.GetOkExists("provisionable")
+	free := d.Get("free").(bool)
+
+	ids := make([]string, 0, len(reservations))
+	rs := make([]map[string]interface{}, 0, len(reservations))
+	for _, r := range reservations {
+		if plan != "" && plan != r.planSlug() {
+			continue
+		}
+		if facility != "" && facility != r.facilityCode() {
+			continue
+		}
+		if provisionableSet && provisionable.(bool) != r.Provisionable {
+			continue
+		}
+		if free && !r.free() {
+			continue
+		}
+
+		ids = append(ids, r.ID)
+		rs = append(rs, map[string]interface{}{
+			"id":            r.ID,
+			"short_id":      r.ShortID,
+			"plan":          r.planSlug(),
+			"facility":      r.facilityCode(),
+			"provisionable": r.Provisionable,
+			"spare":         r.Spare,
+			"device_id":     r.deviceID(),
+			"created":       r.Created,
+		})
+	}
+
+	d.SetId(projectID)
+	d.Set("ids", ids)
+	d.Set("reservations", rs)
+
+	return nil
+}
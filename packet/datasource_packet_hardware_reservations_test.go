@@ -0,0 +1,63 @@
+package packet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/packethost/packngo"
+)
+
+func TestAccPacketHardwareReservationsBasic(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testHardwareReservationsConfigBasic(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.packet_hardware_reservations.test", "ids.#", "0"),
+					resource.TestCheckResourceAttr(
+						"data.packet_hardware_reservations.test", "reservations.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestHardwareReservationFree(t *testing.T) {
+	r := &hardwareReservation{Provisionable: true}
+	if !r.free() {
+		t.Fatalf("expected provisionable reservation without device to be free")
+	}
+
+	r.Device = &packngo.Device{Href: "/devices/6e1c9a5d-0b6a-4b0e-8f1c-4c5b2e3f4a5b"}
+	if r.free() {
+		t.Fatalf("expected reservation with device to be used")
+	}
+	if r.deviceID() != "6e1c9a5d-0b6a-4b0e-8f1c-4c5b2e3f4a5b" {
+		t.Fatalf("unexpected device ID %s", r.deviceID())
+	}
+
+	r = &hardwareReservation{Provisionable: false}
+	if r.free() {
+		t.Fatalf("expected reservation which is not provisionable to be used")
+	}
+}
+
+func testHardwareReservationsConfigBasic(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "%s"
+}
+
+data "packet_hardware_reservations" "test" {
+    project_id = "${packet_project.test.id}"
+    free       = true
+}
+`, name)
+}
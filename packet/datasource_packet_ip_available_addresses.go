@@ -0,0 +1,84 @@
+package packet
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func dataSourcePacketIPAvailableAddresses() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePacketIPAvailableAddressesRead,
+		Schema: map[string]*schema.Schema{
+			"reserved_block_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cidr": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"available": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"total_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"free_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"used_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcePacketIPAvailableAddressesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	blockID := d.Get("reserved_block_id").(string)
+	cidr := d.Get("cidr").(int)
+
+	block, _, err := client.ProjectIPs.Get(blockID)
+	if err != nil {
+		return fmt.Errorf("Error reading IP address block with ID %s: %s", blockID, friendlyError(err))
+	}
+
+	// Addresses are counted in units of the smallest assignable subnet,
+	// which is /32 for IPv4 and /64 for IPv6.
+	unit := 32
+	if block.AddressFamily == 6 {
+		unit = 64
+	}
+	if cidr < block.CIDR || cidr > unit {
+		return fmt.Errorf("\"cidr\" must be between %d and %d for block %s, got %d", block.CIDR, unit, blockID, cidr)
+	}
+
+	available, err := availableSubnets(client, blockID, cidr)
+	if err != nil {
+		return err
+	}
+
+	free := available
+	if cidr != unit {
+		free, err = availableSubnets(client, blockID, unit)
+		if err != nil {
+			return err
+		}
+	}
+	total := 1 << uint(unit-block.CIDR)
+
+	d.SetId(fmt.Sprintf("%s-%d", blockID, cidr))
+	d.Set("available", available)
+	d.Set("total_count", total)
+	d.Set("free_count", len(free))
+	d.Set("used_count", total-len(free))
+
+	return nil
+}
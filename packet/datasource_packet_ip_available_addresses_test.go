@@ -0,0 +1,53 @@
+package packet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccPacketIPAvailableAddressesBasic(t *testing.T) {
+
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testIPAvailableAddressesConfigBasic(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.packet_ip_available_addresses.test", "available.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.packet_ip_available_addresses.test", "total_count", "4"),
+					resource.TestCheckResourceAttr(
+						"data.packet_ip_available_addresses.test", "free_count", "4"),
+					resource.TestCheckResourceAttr(
+						"data.packet_ip_available_addresses.test", "used_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testIPAvailableAddressesConfigBasic(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "%s"
+}
+
+resource "packet_reserved_ip_block" "test" {
+    project_id = "${packet_project.test.id}"
+    facility = "ewr1"
+    quantity = 4
+}
+
+data "packet_ip_available_addresses" "test" {
+    reserved_block_id = "${packet_reserved_ip_block.test.id}"
+    cidr = 31
+}
+`, name)
+}
@@ -0,0 +1,135 @@
+package packet
+
+import (
+	"fmt"
+	"log"
+	"path"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func dataSourcePacketIPBlocks() *schema.Resource {
+	blockSchema := packetIPComputedFields()
+	blockSchema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	blockSchema["facility"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	blockSchema["global"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+	}
+	blockSchema["cidr_notation"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	blockSchema["assignment_ids"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	blockSchema["device_ids"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	return &schema.Resource{
+		Read: dataSourcePacketIPBlocksRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"facility": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"address_family": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"public": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"global": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"blocks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: blockSchema,
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePacketIPBlocksRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	projectID := d.Get("project_id").(string)
+	log.Println("[DEBUG] packet_ip_blocks - getting list of IPs in a project")
+
+	reservations, err := listIPReservations(client, projectID)
+	if err != nil {
+		return err
+	}
+
+	facility := d.Get("facility").(string)
+	family := d.Get("address_family").(int)
+	public, filterPublic := d.GetOkExists("public")
+	global, filterGlobal := d.GetOkExists("global")
+
+	blocks := make([]map[string]interface{}, 0, len(reservations))
+	for _, r := range reservations {
+		if facility != "" && facility != r.Facility.Code {
+			continue
+		}
+		if family != 0 && family != r.AddressFamily {
+			continue
+		}
+		if filterPublic && public.(bool) != r.Public {
+			continue
+		}
+		if filterGlobal && global.(bool) != r.Global {
+			continue
+		}
+
+		assignmentIDs := make([]string, 0, len(r.Assignments))
+		deviceIDs := make([]string, 0, len(r.Assignments))
+		for _, a := range r.Assignments {
+			assignmentIDs = append(assignmentIDs, path.Base(a.Href))
+			deviceIDs = append(deviceIDs, path.Base(a.AssignedTo.Href))
+		}
+
+		blocks = append(blocks, map[string]interface{}{
+			"id":             r.ID,
+			"address":        r.Address,
+			"gateway":        r.Gateway,
+			"network":        r.Network,
+			"netmask":        r.Netmask,
+			"cidr":           r.CIDR,
+			"address_family": r.AddressFamily,
+			"public":         r.Public,
+			"management":     r.Management,
+			"manageable":     r.Manageable,
+			"facility":       r.Facility.Code,
+			"global":         r.Global,
+			"cidr_notation":  fmt.Sprintf("%s/%d", r.Network, r.CIDR),
+			"assignment_ids": assignmentIDs,
+			"device_ids":     deviceIDs,
+		})
+	}
+
+	d.SetId(projectID)
+	d.Set("blocks", blocks)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package packet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccPacketIPBlocksBasic(t *testing.T) {
+
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testIPBlocksConfigBasic(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.packet_ip_blocks.test", "blocks.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.packet_ip_blocks.test", "blocks.0.id",
+						"packet_reserved_ip_block.test", "id"),
+					resource.TestCheckResourceAttr(
+						"data.packet_ip_blocks.test", "blocks.0.device_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testIPBlocksConfigBasic(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "%s"
+}
+
+resource "packet_reserved_ip_block" "test" {
+    project_id = "${packet_project.test.id}"
+    facility = "ewr1"
+    quantity = 2
+}
+
+data "packet_ip_blocks" "test" {
+    project_id     = "${packet_reserved_ip_block.test.project_id}"
+    facility       = "ewr1"
+    address_family = 4
+    public         = true
+    global         = false
+}
+`, name)
+}
@@ -0,0 +1,71 @@
+package packet
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func dataSourcePacketOrganization() *schema.Resource {
+	orgSchema := organizationFields()
+	for _, s := range orgSchema {
+		s.Required = false
+		s.Optional = false
+		s.Computed = true
+	}
+	orgSchema["name"].Optional = true
+	orgSchema["name"].ConflictsWith = []string{"organization_id"}
+	orgSchema["organization_id"] = &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"name"},
+	}
+
+	return &schema.Resource{
+		Read:   dataSourcePacketOrganizationRead,
+		Schema: orgSchema,
+	}
+}
+
+func dataSourcePacketOrganizationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	orgID := d.Get("organization_id").(string)
+	name := d.Get("name").(string)
+
+	var org *packngo.Organization
+	switch {
+	case orgID != "":
+		var err error
+		org, _, err = client.Organizations.Get(orgID)
+		if err != nil {
+			return friendlyError(err)
+		}
+	case name != "":
+		log.Println("[DEBUG] packet_organization - getting list of organizations")
+		orgs, _, err := client.Organizations.List()
+		if err != nil {
+			return friendlyError(err)
+		}
+		for i, o := range orgs {
+			if o.Name == name {
+				if org != nil {
+					return fmt.Errorf("There are more organizations named %q, use \"organization_id\" instead", name)
+				}
+				org = &orgs[i]
+			}
+		}
+		if org == nil {
+			return fmt.Errorf("Could not find organization named %q", name)
+		}
+	default:
+		return fmt.Errorf("one of \"organization_id\" or \"name\" must be provided")
+	}
+
+	loadOrganization(d, org)
+	d.Set("organization_id", org.ID)
+
+	return nil
+}
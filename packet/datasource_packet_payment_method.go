@@ -0,0 +1,156 @@
+package packet
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+// paymentMethod is a payment method on file in an organization, with the
+// card type, which packngo.PaymentMethod lacks.
+type paymentMethod struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Type            string `json:"type,omitempty"`
+	CardType        string `json:"card_type,omitempty"`
+	CardholderName  string `json:"cardholder_name,omitempty"`
+	Last4           string `json:"last_4,omitempty"`
+	ExpirationMonth string `json:"expiration_month,omitempty"`
+	ExpirationYear  string `json:"expiration_year,omitempty"`
+	Default         bool   `json:"default"`
+	Created         string `json:"created_at,omitempty"`
+	Updated         string `json:"updated_at,omitempty"`
+	Href            string `json:"href,omitempty"`
+}
+
+// expired tells whether the card expired before t. Cards are valid until the
+// end of their expiration month. Payment methods without expiration, or with
+// one which can't be parsed, are considered valid.
+func (pm *paymentMethod) expired(t time.Time) bool {
+	month, err := strconv.Atoi(pm.ExpirationMonth)
+	if err != nil {
+		return false
+	}
+	year, err := strconv.Atoi(pm.ExpirationYear)
+	if err != nil {
+		return false
+	}
+	if year < 100 {
+		year += 2000
+	}
+	validUntil := time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC)
+	return !t.Before(validUntil)
+}
+
+func dataSourcePacketPaymentMethod() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePacketPaymentMethodRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"card_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cardholder_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last4": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expiration_month": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expiration_year": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expired": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"default": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcePacketPaymentMethodRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	orgID := d.Get("organization_id").(string)
+	name := d.Get("name").(string)
+	cardType := d.Get("card_type").(string)
+
+	log.Println("[DEBUG] packet_payment_method - getting list of payment methods in an organization")
+	paymentMethods, err := listPaymentMethods(client, orgID)
+	if err != nil {
+		return err
+	}
+
+	var found *paymentMethod
+	for i, pm := range paymentMethods {
+		if name != "" && name != pm.Name {
+			continue
+		}
+		if cardType != "" && !strings.EqualFold(cardType, pm.CardType) {
+			continue
+		}
+		if found != nil {
+			return fmt.Errorf("More payment methods in organization %s match the criteria, please specify them more precisely", orgID)
+		}
+		found = &paymentMethods[i]
+	}
+	if found == nil {
+		return fmt.Errorf("Could not find a payment method matching the criteria in organization %s", orgID)
+	}
+
+	d.SetId(found.ID)
+	d.Set("name", found.Name)
+	d.Set("card_type", found.CardType)
+	d.Set("type", found.Type)
+	d.Set("cardholder_name", found.CardholderName)
+	d.Set("last4", found.Last4)
+	d.Set("expiration_month", found.ExpirationMonth)
+	d.Set("expiration_year", found.ExpirationYear)
+	d.Set("expired", found.expired(time.Now()))
+	d.Set("default", found.Default)
+	d.Set("href", found.Href)
+	d.Set("created", found.Created)
+	d.Set("updated", found.Updated)
+
+	return nil
+}
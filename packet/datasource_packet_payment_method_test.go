@@ -0,0 +1,28 @@
+package packet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPaymentMethodExpired(t *testing.T) {
+	now := time.Date(2017, time.December, 15, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		month, year string
+		expired     bool
+	}{
+		{"11", "2017", true},
+		{"12", "2017", false},
+		{"1", "2018", false},
+		{"12", "16", true},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		pm := &paymentMethod{ExpirationMonth: c.month, ExpirationYear: c.year}
+		if pm.expired(now) != c.expired {
+			t.Fatalf("expected expired=%t for %s/%s", c.expired, c.month, c.year)
+		}
+	}
+}
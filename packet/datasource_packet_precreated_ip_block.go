@@ -1,9 +1,13 @@
 package packet
 
 import (
+	"bytes"
 	"fmt"
 	"log"
+	"net"
+	"sort"
 
+	"github.com/apparentlymart/go-cidr/cidr"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/packethost/packngo"
 )
@@ -28,6 +32,10 @@ func dataSourcePacketPreCreatedIPBlock() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"block_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"quantity": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -54,16 +62,23 @@ func dataSourcePacketPreCreatedIPBlock() *schema.Resource {
 			},
 			"cidr": {
 				Type:     schema.TypeInt,
+				Optional: true,
 				Computed: true,
 			},
 			"management": {
 				Type:     schema.TypeBool,
+				Optional: true,
 				Computed: true,
 			},
 			"manageable": {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"ipv6_subnets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -74,21 +89,83 @@ func dataSourcePacketReservedIPBlockRead(d *schema.ResourceData, meta interface{
 	log.Println("[DEBUG] packet_precreated_ip_block - getting list of IPs in a project")
 	ips, _, err := client.ProjectIPs.List(projectID)
 	if err != nil {
-		return err
+		return friendlyError(err)
 	}
 	ipv := d.Get("address_family").(int)
 	public := d.Get("public").(bool)
 	facility := d.Get("facility").(string)
+	blockID := d.Get("block_id").(string)
+	cidr := d.Get("cidr").(int)
+	management, filterManagement := d.GetOkExists("management")
 
+	matches := []packngo.IPAddressReservation{}
 	for _, ip := range ips {
-		if ip.Public == public && ip.AddressFamily == ipv && facility == ip.Facility.Code {
-			loadBlock(d, &ip)
-			break
+		if ip.Public != public || ip.AddressFamily != ipv || facility != ip.Facility.Code {
+			continue
+		}
+		if blockID != "" && blockID != ip.ID {
+			continue
+		}
+		if cidr != 0 && cidr != ip.CIDR {
+			continue
+		}
+		if filterManagement && management.(bool) != ip.Management {
+			continue
+		}
+		matches = append(matches, ip)
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("Could not find IPv%d %s block in facility %s of project %s matching the given arguments",
+			ipv, map[bool]string{true: "public", false: "private"}[public], facility, projectID)
+	}
+
+	// The API doesn't guarantee order of the blocks, so if more of them match,
+	// prefer the precreated (management) ones, then the largest and then
+	// the one with the lowest network address.
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Management != b.Management {
+			return a.Management
+		}
+		if a.CIDR != b.CIDR {
+			return a.CIDR < b.CIDR
 		}
+		return bytes.Compare(net.ParseIP(a.Network).To16(), net.ParseIP(b.Network).To16()) < 0
+	})
+	block := matches[0]
+
+	if err := loadBlock(d, &block); err != nil {
+		return err
 	}
-	if d.Get("cidr_notation") == "" {
-		return fmt.Errorf("Could not find matching reserved block, all IPs were %v", ips)
+
+	subnets, err := ipv6Subnets64(&block)
+	if err != nil {
+		return err
 	}
+	d.Set("ipv6_subnets", subnets)
+
 	return nil
+}
 
+// ipv6Subnets64 splits an IPv6 block to the /64 subnets, which is the longest
+// prefix assignable to a device. Blocks larger than /56 are not split.
+func ipv6Subnets64(block *packngo.IPAddressReservation) ([]string, error) {
+	subnets := []string{}
+	bits := 64 - block.CIDR
+	if block.AddressFamily != 6 || bits < 0 || bits > 8 {
+		return subnets, nil
+	}
+
+	_, network, err := net.ParseCIDR(fmt.Sprintf("%s/%d", block.Network, block.CIDR))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing network of IP block %s: %s", block.ID, err)
+	}
+	for i := 0; i < 1<<uint(bits); i++ {
+		subnet, err := cidr.Subnet(network, bits, i)
+		if err != nil {
+			return nil, err
+		}
+		subnets = append(subnets, subnet.String())
+	}
+	return subnets, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/packethost/packngo"
 )
 
 func TestAccPacketPreCreatedIPBlockBasic(t *testing.T) {
@@ -21,6 +22,8 @@ func TestAccPacketPreCreatedIPBlockBasic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"data.packet_precreated_ip_block.test", "cidr_notation"),
+					resource.TestCheckResourceAttr(
+						"data.packet_precreated_ip_block.test", "ipv6_subnets.#", "256"),
 					resource.TestCheckResourceAttrPair(
 						"packet_ip_attachment.test", "device_id",
 						"packet_device.test", "id"),
@@ -35,6 +38,39 @@ func TestAccPacketPreCreatedIPBlockBasic(t *testing.T) {
 	})
 }
 
+func TestIPv6Subnets64(t *testing.T) {
+	block := &packngo.IPAddressReservation{}
+	block.ID = "test"
+	block.AddressFamily = 6
+	block.Network = "2604:1380:1:5f00::"
+	block.CIDR = 56
+
+	subnets, err := ipv6Subnets64(block)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(subnets) != 256 {
+		t.Fatalf("expected 256 subnets, got %d", len(subnets))
+	}
+	if subnets[0] != "2604:1380:1:5f00::/64" {
+		t.Fatalf("unexpected first subnet %s", subnets[0])
+	}
+	if subnets[255] != "2604:1380:1:5fff::/64" {
+		t.Fatalf("unexpected last subnet %s", subnets[255])
+	}
+
+	block.AddressFamily = 4
+	block.Network = "10.0.0.0"
+	block.CIDR = 25
+	subnets, err = ipv6Subnets64(block)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(subnets) != 0 {
+		t.Fatalf("expected no subnets for IPv4 block, got %v", subnets)
+	}
+}
+
 func testPreCreatedIPBlockConfigBasic(name string) string {
 	return fmt.Sprintf(`
 
@@ -56,11 +92,12 @@ data "packet_precreated_ip_block" "test" {
     project_id       = "${packet_device.test.project_id}"
     address_family   = 6
     public           = true
+    management       = true
 }
 
 resource "packet_ip_attachment" "test" {
     device_id = "${packet_device.test.id}"
-    cidr_notation = "${cidrsubnet(data.packet_precreated_ip_block.test.cidr_notation,8,2)}"
+    cidr_notation = "${data.packet_precreated_ip_block.test.ipv6_subnets[2]}"
 }
 `, name)
 }
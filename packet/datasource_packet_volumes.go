@@ -0,0 +1,198 @@
+package packet
+
+import (
+	"log"
+	"path"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func dataSourcePacketVolumes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePacketVolumesRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"facility": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"plan": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"device_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"unattached"},
+			},
+			"unattached": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"plan": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"facility": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"billing_cycle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"locked": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"device_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePacketVolumesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	projectID := d.Get("project_id").(string)
+	log.Println("[DEBUG] packet_volumes - getting list of volumes in a project")
+	// Without the includes, the facility code and the attached devices are
+	// missing from the list.
+	listOpt := &packngo.ListOptions{Includes: "facility,attachments.device"}
+	volumes, _, err := client.Volumes.List(projectID, listOpt)
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	facility := d.Get("facility").(string)
+	plan := d.Get("plan").(string)
+	state := d.Get("state").(string)
+	name := d.Get("name").(string)
+	deviceID := d.Get("device_id").(string)
+	unattached := d.Get("unattached").(bool)
+
+	ids := make([]string, 0, len(volumes))
+	vols := make([]map[string]interface{}, 0, len(volumes))
+	for _, v := range volumes {
+		deviceIDs := volumeDeviceIDs(&v)
+
+		vFacility := ""
+		if v.Facility != nil {
+			vFacility = v.Facility.Code
+		}
+		vPlan := ""
+		if v.Plan != nil {
+			vPlan = v.Plan.Slug
+		}
+
+		if facility != "" && facility != vFacility {
+			continue
+		}
+		if plan != "" && plan != vPlan {
+			continue
+		}
+		if state != "" && state != v.State {
+			continue
+		}
+		if name != "" && name != v.Name {
+			continue
+		}
+		if deviceID != "" && !stringInSlice(deviceID, deviceIDs) {
+			continue
+		}
+		if unattached && len(deviceIDs) > 0 {
+			continue
+		}
+
+		ids = append(ids, v.ID)
+		vols = append(vols, map[string]interface{}{
+			"id":            v.ID,
+			"name":          v.Name,
+			"description":   v.Description,
+			"size":          v.Size,
+			"state":         v.State,
+			"plan":          vPlan,
+			"facility":      vFacility,
+			"billing_cycle": v.BillingCycle,
+			"locked":        v.Locked,
+			"device_ids":    deviceIDs,
+			"created":       v.Created,
+			"updated":       v.Updated,
+		})
+	}
+
+	d.SetId(projectID)
+	d.Set("ids", ids)
+	d.Set("volumes", vols)
+
+	return nil
+}
+
+// volumeDeviceIDs returns IDs of devices to which the volume is attached.
+func volumeDeviceIDs(v *packngo.Volume) []string {
+	ids := make([]string, 0, len(v.Attachments))
+	for _, a := range v.Attachments {
+		if a.Device.ID != "" {
+			ids = append(ids, a.Device.ID)
+		} else if a.Device.Href != "" {
+			ids = append(ids, path.Base(a.Device.Href))
+		}
+	}
+	return ids
+}
@@ -0,0 +1,59 @@
+package packet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccPacketVolumesBasic(t *testing.T) {
+
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testVolumesConfigBasic(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.packet_volumes.test", "volumes.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.packet_volumes.test", "ids.0",
+						"packet_volume.test", "id"),
+					resource.TestCheckResourceAttr(
+						"data.packet_volumes.test", "volumes.0.size", "100"),
+					resource.TestCheckResourceAttr(
+						"data.packet_volumes.test", "volumes.0.facility", "ewr1"),
+					resource.TestCheckResourceAttr(
+						"data.packet_volumes.test", "volumes.0.device_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testVolumesConfigBasic(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "%s"
+}
+
+resource "packet_volume" "test" {
+    plan = "storage_1"
+    billing_cycle = "hourly"
+    size = 100
+    project_id = "${packet_project.test.id}"
+    facility = "ewr1"
+}
+
+data "packet_volumes" "test" {
+    project_id = "${packet_volume.test.project_id}"
+    facility   = "ewr1"
+    unattached = true
+}
+`, name)
+}
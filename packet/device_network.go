@@ -0,0 +1,238 @@
+package packet
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/packethost/packngo"
+)
+
+const (
+	networkTypeL3           = "layer3"
+	networkTypeHybrid       = "hybrid"
+	networkTypeL2Bonded     = "layer2-bonded"
+	networkTypeL2Individual = "layer2-individual"
+)
+
+var deviceNetworkTypes = []string{networkTypeL3, networkTypeHybrid, networkTypeL2Bonded, networkTypeL2Individual}
+
+// devicePort is a network port of a device. Unlike packngo.Port, it has the
+// network type and the native VLAN of the port.
+type devicePort struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	NetworkType string `json:"network_type,omitempty"`
+	Data        struct {
+		Bonded bool   `json:"bonded"`
+		MAC    string `json:"mac,omitempty"`
+	} `json:"data"`
+	VirtualNetworks      []packngo.Href `json:"virtual_networks,omitempty"`
+	NativeVirtualNetwork *packngo.Href  `json:"native_virtual_network,omitempty"`
+}
+
+// hasVirtualNetwork tells whether the VLAN is assigned to the port.
+func (p *devicePort) hasVirtualNetwork(vlanID string) bool {
+	for _, vn := range p.VirtualNetworks {
+		if path.Base(vn.Href) == vlanID {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *packetDevice) port(name string) *devicePort {
+	for i := range d.NetworkPorts {
+		if d.NetworkPorts[i].Name == name {
+			return &d.NetworkPorts[i]
+		}
+	}
+	return nil
+}
+
+// physicalPorts returns the ports which can be bonded to bond0, i.e. eth0,
+// eth1, ...
+func (d *packetDevice) physicalPorts() []*devicePort {
+	ports := []*devicePort{}
+	for i := range d.NetworkPorts {
+		if strings.HasPrefix(d.NetworkPorts[i].Name, "eth") {
+			ports = append(ports, &d.NetworkPorts[i])
+		}
+	}
+	return ports
+}
+
+// networkType infers the network type of the device from the state of its
+// ports. Devices without bond0 port only support layer3.
+func (d *packetDevice) networkType() string {
+	bond0 := d.port("bond0")
+	if bond0 == nil {
+		return networkTypeL3
+	}
+
+	allBonded := true
+	for _, p := range d.physicalPorts() {
+		if !p.Data.Bonded {
+			allBonded = false
+		}
+	}
+
+	if bond0.NetworkType == "layer2" {
+		if allBonded {
+			return networkTypeL2Bonded
+		}
+		return networkTypeL2Individual
+	}
+
+	if allBonded {
+		return networkTypeL3
+	}
+	return networkTypeHybrid
+}
+
+func (d *packetDevice) flattenPorts() []map[string]interface{} {
+	ports := make([]map[string]interface{}, 0, len(d.NetworkPorts))
+	for _, p := range d.NetworkPorts {
+		ports = append(ports, map[string]interface{}{
+			"id":     p.ID,
+			"name":   p.Name,
+			"type":   p.Type,
+			"bonded": p.Data.Bonded,
+			"mac":    p.Data.MAC,
+		})
+	}
+	return ports
+}
+
+func bondPort(client *packngo.Client, p *devicePort) error {
+	log.Printf("[DEBUG] Bonding port %s (%s)", p.Name, p.ID)
+	_, _, err := client.DevicePorts.Bond(&packngo.BondRequest{PortID: p.ID, BulkEnable: false})
+	return friendlyError(err)
+}
+
+func disbondPort(client *packngo.Client, p *devicePort) error {
+	log.Printf("[DEBUG] Disbonding port %s (%s)", p.Name, p.ID)
+	_, _, err := client.DevicePorts.Disbond(&packngo.DisbondRequest{PortID: p.ID, BulkDisable: false})
+	return friendlyError(err)
+}
+
+// defaultDeviceIPAddresses are requested when bond0 is converted back to
+// layer 3 and the device has no ip_address configured. They are the same
+// as the addresses new devices get.
+var defaultDeviceIPAddresses = []deviceIPAddress{
+	{AddressFamily: 4, Public: true},
+	{AddressFamily: 4, Public: false},
+	{AddressFamily: 6, Public: true},
+}
+
+// setDeviceNetworkType converts the ports of the device so that it ends up
+// in the target network type, and waits until the conversion is done.
+// Conversion of bond0 to layer 3 requests ipAddresses, or the default
+// addresses if there are none.
+func setDeviceNetworkType(client *packngo.Client, deviceID, target string, ipAddresses []deviceIPAddress) error {
+	device, err := getDevice(client, deviceID)
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	current := device.networkType()
+	if current == target {
+		return nil
+	}
+	log.Printf("[DEBUG] Converting network of device %s from %s to %s", deviceID, current, target)
+
+	bond0 := device.port("bond0")
+	if bond0 == nil {
+		return fmt.Errorf("device %s has no bond0 port, only %q network type is supported", deviceID, networkTypeL3)
+	}
+	eths := device.physicalPorts()
+
+	toLayer2 := target == networkTypeL2Bonded || target == networkTypeL2Individual
+
+	// Ports must be bonded for conversion of bond0 between layer 2 and
+	// layer 3.
+	if toLayer2 != (bond0.NetworkType == "layer2") {
+		for _, p := range eths {
+			if !p.Data.Bonded {
+				if err := bondPort(client, p); err != nil {
+					return err
+				}
+			}
+		}
+
+		if toLayer2 {
+			_, _, err = client.DevicePorts.PortToLayerTwo(bond0.ID)
+		} else {
+			if len(ipAddresses) == 0 {
+				ipAddresses = defaultDeviceIPAddresses
+			}
+			err = convertPortToLayer3(client, bond0.ID, ipAddresses)
+		}
+		if err != nil {
+			return friendlyError(err)
+		}
+
+		device, err = getDevice(client, deviceID)
+		if err != nil {
+			return friendlyError(err)
+		}
+		eths = device.physicalPorts()
+	}
+
+	switch target {
+	case networkTypeL3, networkTypeL2Bonded:
+		for _, p := range eths {
+			if !p.Data.Bonded {
+				if err := bondPort(client, p); err != nil {
+					return err
+				}
+			}
+		}
+	case networkTypeHybrid:
+		if eth1 := device.port("eth1"); eth1 != nil && eth1.Data.Bonded {
+			if err := disbondPort(client, eth1); err != nil {
+				return err
+			}
+		}
+	case networkTypeL2Individual:
+		for _, p := range eths {
+			if p.Data.Bonded {
+				if err := disbondPort(client, p); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return waitForDeviceNetworkType(client, deviceID, target)
+}
+
+func waitForDeviceNetworkType(client *packngo.Client, deviceID, target string) error {
+	pending := []string{}
+	for _, t := range deviceNetworkTypes {
+		if t != target {
+			pending = append(pending, t)
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: pending,
+		Target:  []string{target},
+		Refresh: func() (interface{}, string, error) {
+			device, err := getDevice(client, deviceID)
+			if err != nil {
+				return nil, "", friendlyError(err)
+			}
+			return device, device.networkType(), nil
+		},
+		Timeout:    20 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
@@ -0,0 +1,225 @@
+package packet
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	storageRaidMinDevices = map[string]int{"0": 2, "1": 2, "5": 3, "6": 4, "10": 4}
+	storageFormats        = []string{"ext2", "ext3", "ext4", "xfs", "btrfs", "vfat", "swap"}
+	matchStorageSize      = regexp.MustCompile(`^\d+[KMGT]?$`)
+)
+
+// storageLayout is the custom partitioning and RAID layout of a device, as
+// accepted by the Packet API in the "storage" field of the create request.
+type storageLayout struct {
+	Disks       []storageDisk       `json:"disks"`
+	Raid        []storageRaid       `json:"raid,omitempty"`
+	Filesystems []storageFilesystem `json:"filesystems,omitempty"`
+}
+
+type storageDisk struct {
+	Device     string             `json:"device"`
+	WipeTable  bool               `json:"wipeTable,omitempty"`
+	Partitions []storagePartition `json:"partitions,omitempty"`
+}
+
+type storagePartition struct {
+	Label  string      `json:"label"`
+	Number int         `json:"number"`
+	Size   interface{} `json:"size"`
+}
+
+type storageRaid struct {
+	Devices []string `json:"devices"`
+	Level   string   `json:"level"`
+	Name    string   `json:"name"`
+}
+
+type storageFilesystem struct {
+	Mount struct {
+		Device string `json:"device"`
+		Format string `json:"format"`
+		Point  string `json:"point,omitempty"`
+		Create *struct {
+			Options []string `json:"options,omitempty"`
+		} `json:"create,omitempty"`
+	} `json:"mount"`
+}
+
+func parseStorageLayout(s string) (*storageLayout, error) {
+	layout := new(storageLayout)
+	if err := json.Unmarshal([]byte(s), layout); err != nil {
+		return nil, err
+	}
+	if err := checkStorageFields(json.RawMessage(s), reflect.TypeOf(layout), ""); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+// checkStorageFields fails on keys of JSON objects, nested ones included,
+// which don't match a field of the corresponding type, so that misspelled
+// keys aren't silently dropped from the layout. Like json.Unmarshal, it
+// matches the keys case-insensitively.
+func checkStorageFields(raw json.RawMessage, t reflect.Type, path string) error {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return checkStorageFields(raw, t.Elem(), path)
+	case reflect.Slice:
+		items := []json.RawMessage{}
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+		for i, item := range items {
+			if err := checkStorageFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			field, ok := storageField(t, key)
+			if !ok {
+				return fmt.Errorf("unknown field %q", keyPath)
+			}
+			if err := checkStorageFields(fields[key], field.Type, keyPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// storageField returns the field of the struct type t to which the JSON key
+// is decoded.
+func storageField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = f.Name
+		}
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// validate checks the layout for mistakes which the API would only report
+// after the device is requested, or which would leave the device unbootable.
+func (l *storageLayout) validate() []error {
+	errs := []error{}
+	if len(l.Disks) == 0 {
+		errs = append(errs, fmt.Errorf("at least one disk must be specified"))
+	}
+
+	// Block devices which RAID arrays and filesystems can be built on.
+	devices := map[string]bool{}
+	for i, disk := range l.Disks {
+		if !strings.HasPrefix(disk.Device, "/dev/") {
+			errs = append(errs, fmt.Errorf("disks[%d]: device must be a path in /dev, got %q", i, disk.Device))
+		}
+		devices[disk.Device] = true
+
+		numbers := map[int]bool{}
+		for j, p := range disk.Partitions {
+			if p.Label == "" {
+				errs = append(errs, fmt.Errorf("disks[%d].partitions[%d]: label must be set", i, j))
+			}
+			if p.Number < 1 {
+				errs = append(errs, fmt.Errorf("disks[%d].partitions[%d]: number must be at least 1, got %d", i, j, p.Number))
+			}
+			if numbers[p.Number] {
+				errs = append(errs, fmt.Errorf("disks[%d].partitions[%d]: number %d is used more than once", i, j, p.Number))
+			}
+			numbers[p.Number] = true
+			if !validStorageSize(p.Size) {
+				errs = append(errs, fmt.Errorf("disks[%d].partitions[%d]: size must be a number of bytes, or a number with K, M, G or T suffix, got %v", i, j, p.Size))
+			}
+
+			devices[fmt.Sprintf("%s%d", disk.Device, p.Number)] = true
+			devices[fmt.Sprintf("%sp%d", disk.Device, p.Number)] = true
+		}
+	}
+
+	for i, r := range l.Raid {
+		if r.Name == "" {
+			errs = append(errs, fmt.Errorf("raid[%d]: name must be set", i))
+		}
+		min, ok := storageRaidMinDevices[r.Level]
+		if !ok {
+			errs = append(errs, fmt.Errorf("raid[%d]: level must be one of 0, 1, 5, 6 or 10, got %q", i, r.Level))
+		} else if len(r.Devices) < min {
+			errs = append(errs, fmt.Errorf("raid[%d]: level %s needs at least %d devices, got %d", i, r.Level, min, len(r.Devices)))
+		}
+		for _, dev := range r.Devices {
+			if !devices[dev] {
+				errs = append(errs, fmt.Errorf("raid[%d]: device %s is not a declared disk or partition", i, dev))
+			}
+		}
+		devices[r.Name] = true
+	}
+
+	for i, fs := range l.Filesystems {
+		m := fs.Mount
+		if !devices[m.Device] {
+			errs = append(errs, fmt.Errorf("filesystems[%d]: device %q is not a declared disk, partition or RAID array", i, m.Device))
+		}
+		if !stringInSlice(m.Format, storageFormats) {
+			errs = append(errs, fmt.Errorf("filesystems[%d]: format must be one of %v, got %q", i, storageFormats, m.Format))
+		}
+		if m.Format != "swap" && !strings.HasPrefix(m.Point, "/") {
+			errs = append(errs, fmt.Errorf("filesystems[%d]: point must be an absolute path, got %q", i, m.Point))
+		}
+	}
+
+	return errs
+}
+
+func validStorageSize(size interface{}) bool {
+	switch s := size.(type) {
+	case float64:
+		return s >= 0 && s == float64(int64(s))
+	case string:
+		return matchStorageSize.MatchString(s)
+	}
+	return false
+}
+
+func validateDeviceStorage(i interface{}, k string) (warns []string, errs []error) {
+	v, ok := i.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	layout, err := parseStorageLayout(v)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s is not a valid storage layout: %s", k, err))
+		return
+	}
+	for _, err := range layout.validate() {
+		errs = append(errs, fmt.Errorf("%s: %s", k, err))
+	}
+	return
+}
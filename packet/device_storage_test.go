@@ -0,0 +1,86 @@
+package packet
+
+import (
+	"strings"
+	"testing"
+)
+
+const testStorageLayoutRaid1 = `{
+  "disks": [
+    {
+      "device": "/dev/sda",
+      "wipeTable": true,
+      "partitions": [
+        {"label": "BIOS", "number": 1, "size": 4096},
+        {"label": "SWAP", "number": 2, "size": "3993600"},
+        {"label": "ROOT", "number": 3, "size": 0}
+      ]
+    },
+    {
+      "device": "/dev/sdb",
+      "wipeTable": true,
+      "partitions": [
+        {"label": "BIOS", "number": 1, "size": 4096},
+        {"label": "SWAP", "number": 2, "size": "3993600"},
+        {"label": "ROOT", "number": 3, "size": 0}
+      ]
+    }
+  ],
+  "raid": [
+    {"devices": ["/dev/sda3", "/dev/sdb3"], "level": "1", "name": "/dev/md/ROOT"}
+  ],
+  "filesystems": [
+    {"mount": {"device": "/dev/md/ROOT", "format": "ext4", "point": "/", "create": {"options": ["-L", "ROOT"]}}},
+    {"mount": {"device": "/dev/sda2", "format": "swap", "point": "none"}}
+  ]
+}`
+
+func TestValidateDeviceStorage(t *testing.T) {
+	if _, errs := validateDeviceStorage(testStorageLayoutRaid1, "storage"); len(errs) > 0 {
+		t.Fatalf("expected valid layout, got %v", errs)
+	}
+
+	cases := []struct {
+		layout string
+		err    string
+	}{
+		{`{"disks": [`, "not a valid storage layout"},
+		{`{"disks": [{"device": "/dev/sda"}], "filesytems": []}`, "unknown field"},
+		{`{"disks": [{"device": "/dev/sda", "wipeTabel": true}]}`, `unknown field "disks[0].wipeTabel"`},
+		{`{"disks": [{"device": "/dev/sda"}], "filesystems": [{"mount": {"device": "/dev/sda", "format": "ext4", "point": "/", "create": {"opts": []}}}]}`, `unknown field "filesystems[0].mount.create.opts"`},
+		{`{"disks": []}`, "at least one disk"},
+		{`{"disks": [{"device": "sda"}]}`, "must be a path in /dev"},
+		{`{"disks": [{"device": "/dev/sda", "partitions": [{"label": "A", "number": 1, "size": "1X"}]}]}`, "size must be"},
+		{`{"disks": [{"device": "/dev/sda", "partitions": [{"label": "A", "number": 1, "size": 1}, {"label": "B", "number": 1, "size": 1}]}]}`, "used more than once"},
+		{`{"disks": [{"device": "/dev/sda", "partitions": [{"label": "A", "number": 1, "size": 1}]}], "raid": [{"devices": ["/dev/sda1"], "level": "1", "name": "/dev/md/A"}]}`, "needs at least 2 devices"},
+		{`{"disks": [{"device": "/dev/sda"}], "raid": [{"devices": ["/dev/sda1", "/dev/sdb1"], "level": "3", "name": "/dev/md/A"}]}`, "level must be one of"},
+		{`{"disks": [{"device": "/dev/sda"}], "filesystems": [{"mount": {"device": "/dev/sdc1", "format": "ext4", "point": "/"}}]}`, "is not a declared"},
+		{`{"disks": [{"device": "/dev/sda"}], "filesystems": [{"mount": {"device": "/dev/sda", "format": "ntfs", "point": "/"}}]}`, "format must be one of"},
+		{`{"disks": [{"device": "/dev/sda"}], "filesystems": [{"mount": {"device": "/dev/sda", "format": "ext4"}}]}`, "absolute path"},
+	}
+
+	for _, c := range cases {
+		_, errs := validateDeviceStorage(c.layout, "storage")
+		found := false
+		for _, err := range errs {
+			if strings.Contains(err.Error(), c.err) {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected error containing %q for %s, got %v", c.err, c.layout, errs)
+		}
+	}
+}
+
+func TestSuppressEquivalentJSONDiffs(t *testing.T) {
+	if !suppressEquivalentJSONDiffs("storage", `{"a": [1, 2], "b": "c"}`, "{\n  \"b\":\"c\",\"a\":[1,2]\n}", nil) {
+		t.Fatalf("expected equivalent JSON documents to be suppressed")
+	}
+	if suppressEquivalentJSONDiffs("storage", `{"a": [1, 2]}`, `{"a": [2, 1]}`, nil) {
+		t.Fatalf("expected different JSON documents not to be suppressed")
+	}
+	if suppressEquivalentJSONDiffs("storage", `{"a": 1}`, `{"a": 1`, nil) {
+		t.Fatalf("expected invalid JSON not to be suppressed")
+	}
+}
@@ -0,0 +1,66 @@
+package packet
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/packethost/packngo"
+)
+
+// deviceTally counts the new devices in one plan or apply, so that a count
+// of devices is checked against the hardware available for all of them, not
+// each device on its own. The provider is configured anew for every plan and
+// apply, so a tally is kept per client.
+type deviceTally struct {
+	planned map[string]int
+	started map[string]int
+}
+
+var (
+	deviceTalliesMu sync.Mutex
+	deviceTallies   = map[*packngo.Client]*deviceTally{}
+)
+
+func tallyFor(client *packngo.Client) *deviceTally {
+	t, ok := deviceTallies[client]
+	if !ok {
+		t = &deviceTally{planned: map[string]int{}, started: map[string]int{}}
+		deviceTallies[client] = t
+	}
+	return t
+}
+
+// planDevice records a new device with the key and returns how many of the
+// devices planned with the key, including this one, have not been requested
+// yet. The hardware of the requested ones is no longer available, so they
+// are not counted again.
+func planDevice(client *packngo.Client, key string) int {
+	deviceTalliesMu.Lock()
+	defer deviceTalliesMu.Unlock()
+
+	t := tallyFor(client)
+	t.planned[key]++
+	return t.planned[key] - t.started[key]
+}
+
+// startDevice records that a device with the key is being requested.
+func startDevice(client *packngo.Client, key string) {
+	deviceTalliesMu.Lock()
+	defer deviceTalliesMu.Unlock()
+
+	tallyFor(client).started[key]++
+}
+
+// deviceTallyKey groups new devices which compete for the same hardware:
+// a hardware reservation, the free reservations of the plan in the facility
+// for next-available, or the stock of the plan in the facility for devices
+// without a reservation.
+func deviceTallyKey(projectID, facility, plan, reservationID string) string {
+	switch reservationID {
+	case "":
+		return fmt.Sprintf("capacity/%s/%s", facility, plan)
+	case "next-available":
+		return fmt.Sprintf("next-available/%s/%s/%s", projectID, facility, plan)
+	}
+	return fmt.Sprintf("reservation/%s", reservationID)
+}
@@ -0,0 +1,34 @@
+package packet
+
+import (
+	"testing"
+
+	"github.com/packethost/packngo"
+)
+
+func TestPlanDevice(t *testing.T) {
+	client := &packngo.Client{}
+	key := deviceTallyKey("project", "ewr1", "baremetal_0", "next-available")
+
+	if n := planDevice(client, key); n != 1 {
+		t.Fatalf("expected 1 planned device, got %d", n)
+	}
+	if n := planDevice(client, key); n != 2 {
+		t.Fatalf("expected 2 planned devices, got %d", n)
+	}
+
+	// Devices already requested are not counted again.
+	startDevice(client, key)
+	if n := planDevice(client, key); n != 2 {
+		t.Fatalf("expected 2 planned devices after one was requested, got %d", n)
+	}
+
+	other := deviceTallyKey("project", "sjc1", "baremetal_0", "next-available")
+	if n := planDevice(client, other); n != 1 {
+		t.Fatalf("expected 1 planned device in another facility, got %d", n)
+	}
+
+	if n := planDevice(&packngo.Client{}, key); n != 1 {
+		t.Fatalf("expected a new client to start a new tally, got %d", n)
+	}
+}
@@ -0,0 +1,35 @@
+package packet
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func stringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func stringInValues(values []string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warns []string, errs []error) {
+		v, ok := i.(string)
+		if !ok {
+			errs = append(errs, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+
+		for _, value := range values {
+			if v == value {
+				return
+			}
+		}
+
+		errs = append(errs, fmt.Errorf("expected %s to be one of %v, got %s", k, values, v))
+		return
+	}
+}
@@ -17,17 +17,31 @@ func Provider() terraform.ResourceProvider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"packet_precreated_ip_block": dataSourcePacketPreCreatedIPBlock(),
+			"packet_precreated_ip_block":    dataSourcePacketPreCreatedIPBlock(),
+			"packet_volumes":                dataSourcePacketVolumes(),
+			"packet_ip_available_addresses": dataSourcePacketIPAvailableAddresses(),
+			"packet_ip_blocks":              dataSourcePacketIPBlocks(),
+			"packet_organization":           dataSourcePacketOrganization(),
+			"packet_payment_method":         dataSourcePacketPaymentMethod(),
+			"packet_device_bgp_neighbors":   dataSourcePacketDeviceBGPNeighbors(),
+			"packet_hardware_reservations":  dataSourcePacketHardwareReservations(),
+			"packet_capacity":               dataSourcePacketCapacity(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"packet_device":            resourcePacketDevice(),
-			"packet_ssh_key":           resourcePacketSSHKey(),
-			"packet_project":           resourcePacketProject(),
-			"packet_volume":            resourcePacketVolume(),
-			"packet_volume_attachment": resourcePacketVolumeAttachment(),
-			"packet_reserved_ip_block": resourcePacketReservedIPBlock(),
-			"packet_ip_attachment":     resourcePacketIPAttachment(),
+			"packet_device":               resourcePacketDevice(),
+			"packet_ssh_key":              resourcePacketSSHKey(),
+			"packet_project":              resourcePacketProject(),
+			"packet_volume":               resourcePacketVolume(),
+			"packet_volume_attachment":    resourcePacketVolumeAttachment(),
+			"packet_reserved_ip_block":    resourcePacketReservedIPBlock(),
+			"packet_ip_attachment":        resourcePacketIPAttachment(),
+			"packet_vlan":                 resourcePacketVlan(),
+			"packet_port_vlan_attachment": resourcePacketPortVlanAttachment(),
+			"packet_organization":         resourcePacketOrganization(),
+			"packet_project_member":       resourcePacketProjectMember(),
+			"packet_project_invitation":   resourcePacketProjectInvitation(),
+			"packet_bgp_session":          resourcePacketBGPSession(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -0,0 +1,115 @@
+package packet
+
+import (
+	"path"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+// bgpSession is a BGP session of a device with the Packet routers.
+type bgpSession struct {
+	ID            string        `json:"id,omitempty"`
+	Status        string        `json:"status,omitempty"`
+	AddressFamily string        `json:"address_family"`
+	DefaultRoute  bool          `json:"default_route"`
+	Device        *packngo.Href `json:"device,omitempty"`
+}
+
+func resourcePacketBGPSession() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePacketBGPSessionCreate,
+		Read:   resourcePacketBGPSessionRead,
+		Delete: resourcePacketBGPSessionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"address_family": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: stringInValues([]string{"ipv4", "ipv6"}),
+			},
+
+			"default_route": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePacketBGPSessionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	deviceID := d.Get("device_id").(string)
+
+	req := &bgpSession{
+		AddressFamily: d.Get("address_family").(string),
+		DefaultRoute:  d.Get("default_route").(bool),
+	}
+
+	session, err := createBGPSession(client, deviceID, req)
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	d.SetId(session.ID)
+
+	return resourcePacketBGPSessionRead(d, meta)
+}
+
+func resourcePacketBGPSessionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	session, err := getBGPSession(client, d.Id())
+	if err != nil {
+		err = friendlyError(err)
+
+		// If the session or its device was deleted out of band, mark as succesfully gone.
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if session.Device != nil {
+		d.Set("device_id", path.Base(session.Device.Href))
+	}
+	d.Set("address_family", session.AddressFamily)
+	d.Set("default_route", session.DefaultRoute)
+	d.Set("status", session.Status)
+
+	return nil
+}
+
+func resourcePacketBGPSessionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	err := deleteBGPSession(client, d.Id())
+	if err != nil {
+		err = friendlyError(err)
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
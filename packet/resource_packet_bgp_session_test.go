@@ -0,0 +1,91 @@
+package packet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/packethost/packngo"
+)
+
+func TestAccPacketBGPSessionBasic(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketBGPSessionDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketBGPSessionConfigBasic(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_project.test", "bgp_config.0.deployment_type", "local"),
+					resource.TestCheckResourceAttr(
+						"packet_project.test", "bgp_config.0.asn", "65000"),
+					resource.TestCheckResourceAttr(
+						"packet_bgp_session.test", "address_family", "ipv4"),
+					resource.TestCheckResourceAttr(
+						"packet_bgp_session.test", "default_route", "true"),
+					resource.TestCheckResourceAttr(
+						"data.packet_device_bgp_neighbors.test", "bgp_neighbors.0.customer_as", "65000"),
+					resource.TestCheckResourceAttrSet(
+						"data.packet_device_bgp_neighbors.test", "bgp_neighbors.0.peer_as"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "packet_bgp_session.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPacketBGPSessionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*packngo.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "packet_bgp_session" {
+			continue
+		}
+		if _, err := getBGPSession(client, rs.Primary.ID); err == nil {
+			return fmt.Errorf("BGP session still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPacketBGPSessionConfigBasic(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "tfacc-%s"
+    bgp_config {
+        deployment_type = "local"
+        asn             = 65000
+        md5             = "C179c28c41a85b"
+    }
+}
+
+resource "packet_device" "test" {
+  hostname         = "tftest-bgp"
+  plan             = "baremetal_0"
+  facility         = "ewr1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+}
+
+resource "packet_bgp_session" "test" {
+    device_id      = "${packet_device.test.id}"
+    address_family = "ipv4"
+    default_route  = true
+}
+
+data "packet_device_bgp_neighbors" "test" {
+    device_id = "${packet_bgp_session.test.device_id}"
+}`, name)
+}
@@ -1,9 +1,12 @@
 package packet
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"path"
+	"reflect"
 	"regexp"
 	"time"
 
@@ -25,6 +28,68 @@ var terminationTimeParsers = []timeParserFunc{
 
 var matchIPXEScript = regexp.MustCompile(`(?i)^#![i]?pxe`)
 
+// deviceStateRescue is the state of devices booted into the rescue OS.
+const deviceStateRescue = "rescue"
+
+var deviceIPAddressTypes = []string{"public_ipv4", "private_ipv4", "public_ipv6"}
+
+// packetDevice adds the ports with their network type, the description and
+// raw custom data to packngo.Device.
+type packetDevice struct {
+	packngo.Device
+	NetworkPorts []devicePort     `json:"network_ports,omitempty"`
+	Description  string           `json:"description,omitempty"`
+	CustomData   *json.RawMessage `json:"customdata,omitempty"`
+}
+
+// deviceIPAddress requests an address of given type for a new device,
+// optionally from specific reserved blocks.
+type deviceIPAddress struct {
+	AddressFamily  int      `json:"address_family"`
+	Public         bool     `json:"public"`
+	CIDR           int      `json:"cidr,omitempty"`
+	IPReservations []string `json:"ip_reservations,omitempty"`
+}
+
+// deviceCreateRequest adds the requested IP addresses, the description and
+// custom data as a JSON object to packngo.DeviceCreateRequest.
+type deviceCreateRequest struct {
+	packngo.DeviceCreateRequest
+	IPAddresses []deviceIPAddress `json:"ip_addresses,omitempty"`
+	Description string            `json:"description,omitempty"`
+	CustomData  json.RawMessage   `json:"customdata,omitempty"`
+}
+
+// expandDeviceIPAddresses returns the addresses configured in ip_address, or
+// nil if the device should get the default ones.
+func expandDeviceIPAddresses(d *schema.ResourceData) []deviceIPAddress {
+	count := d.Get("ip_address.#").(int)
+	if count == 0 {
+		return nil
+	}
+
+	ipAddresses := make([]deviceIPAddress, 0, count)
+	for i := 0; i < count; i++ {
+		key := fmt.Sprintf("ip_address.%d", i)
+		ipa := deviceIPAddress{
+			AddressFamily: 4,
+			Public:        true,
+			CIDR:          d.Get(key + ".cidr").(int),
+		}
+		switch d.Get(key + ".type").(string) {
+		case "private_ipv4":
+			ipa.Public = false
+		case "public_ipv6":
+			ipa.AddressFamily = 6
+		}
+		for _, r := range d.Get(key + ".reservation_ids").([]interface{}) {
+			ipa.IPReservations = append(ipa.IPReservations, r.(string))
+		}
+		ipAddresses = append(ipAddresses, ipa)
+	}
+	return ipAddresses
+}
+
 func resourcePacketDevice() *schema.Resource {
 	return &schema.Resource{
 		Create: resourcePacketDeviceCreate,
@@ -32,6 +97,8 @@ func resourcePacketDevice() *schema.Resource {
 		Update: resourcePacketDeviceUpdate,
 		Delete: resourcePacketDeviceDelete,
 
+		CustomizeDiff: resourcePacketDeviceCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"project_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -45,10 +112,11 @@ func resourcePacketDevice() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// Changing the OS replaces the device, unless reinstall is
+			// enabled, see resourcePacketDeviceCustomizeDiff.
 			"operating_system": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"facility": &schema.Schema{
@@ -80,6 +148,18 @@ func resourcePacketDevice() *schema.Resource {
 				Sensitive: true,
 			},
 
+			"rescue": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"rescue_password": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
 			"locked": &schema.Schema{
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -129,6 +209,71 @@ func resourcePacketDevice() *schema.Resource {
 							Type:     schema.TypeBool,
 							Computed: true,
 						},
+
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"href": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"netmask": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"management": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"manageable": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"network_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: stringInValues(deviceNetworkTypes),
+			},
+
+			"ports": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"bonded": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"mac": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -143,6 +288,18 @@ func resourcePacketDevice() *schema.Resource {
 				Computed: true,
 			},
 
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"custom_data": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateJSONObject,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
+
 			"user_data": &schema.Schema{
 				Type:      schema.TypeString,
 				Optional:  true,
@@ -150,10 +307,40 @@ func resourcePacketDevice() *schema.Resource {
 			},
 
 			"public_ipv4_subnet_size": &schema.Schema{
-				Type:     schema.TypeInt,
-				Computed: true,
+				Type:          schema.TypeInt,
+				Computed:      true,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"ip_address"},
+			},
+
+			"ip_address": &schema.Schema{
+				Type:     schema.TypeList,
 				Optional: true,
 				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: stringInValues(deviceIPAddressTypes),
+						},
+
+						"cidr": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"reservation_ids": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
 			},
 
 			"ipxe_script_url": &schema.Schema{
@@ -213,6 +400,41 @@ func resourcePacketDevice() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"storage": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateDeviceStorage,
+				DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			},
+
+			"reinstall": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"preserve_data": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"deprovision_fast": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -220,14 +442,16 @@ func resourcePacketDevice() *schema.Resource {
 func resourcePacketDeviceCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
-	createRequest := &packngo.DeviceCreateRequest{
-		Hostname:             d.Get("hostname").(string),
-		Plan:                 d.Get("plan").(string),
-		Facility:             d.Get("facility").(string),
-		OS:                   d.Get("operating_system").(string),
-		BillingCycle:         d.Get("billing_cycle").(string),
-		ProjectID:            d.Get("project_id").(string),
-		PublicIPv4SubnetSize: d.Get("public_ipv4_subnet_size").(int),
+	createRequest := &deviceCreateRequest{
+		DeviceCreateRequest: packngo.DeviceCreateRequest{
+			Hostname:             d.Get("hostname").(string),
+			Plan:                 d.Get("plan").(string),
+			Facility:             d.Get("facility").(string),
+			OS:                   d.Get("operating_system").(string),
+			BillingCycle:         d.Get("billing_cycle").(string),
+			ProjectID:            d.Get("project_id").(string),
+			PublicIPv4SubnetSize: d.Get("public_ipv4_subnet_size").(int),
+		},
 	}
 
 	if attr, ok := d.GetOk("user_data"); ok {
@@ -238,6 +462,17 @@ func resourcePacketDeviceCreate(d *schema.ResourceData, meta interface{}) error
 		createRequest.IPXEScriptURL = attr.(string)
 	}
 
+	if attr, ok := d.GetOk("description"); ok {
+		createRequest.Description = attr.(string)
+	}
+
+	if attr, ok := d.GetOk("custom_data"); ok {
+		if _, errs := validateJSONObject(attr, "custom_data"); len(errs) > 0 {
+			return errs[0]
+		}
+		createRequest.CustomData = json.RawMessage(attr.(string))
+	}
+
 	if attr, ok := d.GetOk("hardware_reservation_id"); ok {
 		createRequest.HardwareReservationID = attr.(string)
 	}
@@ -304,7 +539,28 @@ func resourcePacketDeviceCreate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	newDevice, _, err := client.Devices.Create(createRequest)
+	createRequest.IPAddresses = expandDeviceIPAddresses(d)
+
+	if attr, ok := d.GetOk("storage"); ok {
+		// Interpolated layouts are not known when the config is validated.
+		if _, errs := validateDeviceStorage(attr, "storage"); len(errs) > 0 {
+			return errs[0]
+		}
+		createRequest.Storage = attr.(string)
+	}
+
+	startDevice(client, deviceTallyKey(createRequest.ProjectID, createRequest.Facility,
+		createRequest.Plan, createRequest.HardwareReservationID))
+
+	var (
+		newDevice *packngo.Device
+		err       error
+	)
+	if len(createRequest.IPAddresses) > 0 || createRequest.Description != "" || len(createRequest.CustomData) > 0 {
+		newDevice, err = createDevice(client, createRequest)
+	} else {
+		newDevice, _, err = client.Devices.Create(&createRequest.DeviceCreateRequest)
+	}
 	if err != nil {
 		return friendlyError(err)
 	}
@@ -323,13 +579,25 @@ func resourcePacketDeviceCreate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	if attr, ok := d.GetOk("network_type"); ok {
+		if err := setDeviceNetworkType(client, d.Id(), attr.(string), expandDeviceIPAddresses(d)); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("rescue").(bool) {
+		if err := setDeviceRescue(d, meta, true); err != nil {
+			return err
+		}
+	}
+
 	return resourcePacketDeviceRead(d, meta)
 }
 
 func resourcePacketDeviceRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
-	device, _, err := client.Devices.Get(d.Id())
+	device, err := getDevice(client, d.Id())
 	if err != nil {
 		err = friendlyError(err)
 
@@ -351,9 +619,20 @@ func resourcePacketDeviceRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("locked", device.Locked)
 	d.Set("created", device.Created)
 	d.Set("updated", device.Updated)
+	d.Set("description", device.Description)
+	d.Set("custom_data", flattenCustomData(device.CustomData))
 	d.Set("ipxe_script_url", device.IPXEScriptURL)
 	d.Set("always_pxe", device.AlwaysPXE)
-	d.Set("root_password", device.RootPassword)
+
+	// In rescue mode, the API returns the password of the rescue OS.
+	if device.State == deviceStateRescue {
+		d.Set("rescue", true)
+		d.Set("rescue_password", device.RootPassword)
+	} else {
+		d.Set("rescue", false)
+		d.Set("rescue_password", "")
+		d.Set("root_password", device.RootPassword)
+	}
 
 	if len(device.HardwareReservation.Href) > 0 {
 		d.Set("hardware_reservation_id", path.Base(device.HardwareReservation.Href))
@@ -372,11 +651,16 @@ func resourcePacketDeviceRead(d *schema.ResourceData, meta interface{}) error {
 	)
 	for _, ip := range device.Network {
 		network := map[string]interface{}{
-			"address": ip.Address,
-			"gateway": ip.Gateway,
-			"family":  ip.AddressFamily,
-			"cidr":    ip.CIDR,
-			"public":  ip.Public,
+			"address":    ip.Address,
+			"gateway":    ip.Gateway,
+			"family":     ip.AddressFamily,
+			"cidr":       ip.CIDR,
+			"public":     ip.Public,
+			"id":         ip.ID,
+			"href":       ip.Href,
+			"netmask":    ip.Netmask,
+			"management": ip.Management,
+			"manageable": ip.Manageable,
 		}
 		networks = append(networks, network)
 
@@ -396,6 +680,8 @@ func resourcePacketDeviceRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 	d.Set("network", networks)
+	d.Set("network_type", device.networkType())
+	d.Set("ports", device.flattenPorts())
 	d.Set("public_ipv4_subnet_size", ipv4SubnetSize)
 	d.Set("spot_instance", device.SpotInstance)
 	d.Set("spot_price_max", device.SpotPriceMax)
@@ -410,6 +696,12 @@ func resourcePacketDeviceRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("termination_time_remaining", remaining.String())
 	}
 
+	// Devices created without public IPv4 address are only reachable over
+	// the private network.
+	if host == "" {
+		host = d.Get("access_private_ipv4").(string)
+	}
+
 	if host != "" {
 		d.SetConnInfo(map[string]string{
 			"type": "ssh",
@@ -435,9 +727,86 @@ func resourcePacketDeviceUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	if d.HasChange("network_type") {
+		if err := setDeviceNetworkType(client, d.Id(), d.Get("network_type").(string), expandDeviceIPAddresses(d)); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("description") || d.HasChange("custom_data") {
+		update := map[string]interface{}{}
+		if d.HasChange("description") {
+			update["description"] = d.Get("description").(string)
+		}
+		if d.HasChange("custom_data") {
+			customData := json.RawMessage("{}")
+			if v := d.Get("custom_data").(string); v != "" {
+				if _, errs := validateJSONObject(v, "custom_data"); len(errs) > 0 {
+					return errs[0]
+				}
+				customData = json.RawMessage(v)
+			}
+			update["customdata"] = customData
+		}
+		if err := updateDevice(client, d.Id(), update); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("reinstall.0.enabled").(bool) && (d.HasChange("operating_system") || d.HasChange("user_data")) {
+		if err := reinstallDevice(d, meta); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("rescue") {
+		if err := setDeviceRescue(d, meta, d.Get("rescue").(bool)); err != nil {
+			return err
+		}
+	}
+
 	return resourcePacketDeviceRead(d, meta)
 }
 
+// resourcePacketDeviceCustomizeDiff checks at plan time that a new device
+// can be provisioned, and makes a change of operating_system replace the
+// device, as it did before reinstall was supported, unless reinstall is
+// enabled.
+func resourcePacketDeviceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return checkNewDevice(d, meta.(*packngo.Client))
+	}
+	if d.HasChange("operating_system") && !d.Get("reinstall.0.enabled").(bool) {
+		return d.ForceNew("operating_system")
+	}
+	return nil
+}
+
+// checkNewDevice checks the hardware reservation of a planned device, or
+// that the facility has the plan in stock if the device doesn't use one.
+// Arguments which are not known until apply, e.g. the ID of a project created
+// in the same run, skip the check; it runs when the diff is computed again
+// during apply.
+func checkNewDevice(d *schema.ResourceDiff, client *packngo.Client) error {
+	plan, okPlan := d.GetOk("plan")
+	facility, okFacility := d.GetOk("facility")
+	if !okPlan || !okFacility {
+		return nil
+	}
+
+	if reservationID, ok := d.GetOk("hardware_reservation_id"); ok {
+		projectID, ok := d.GetOk("project_id")
+		if !ok {
+			return nil
+		}
+		count := planDevice(client, deviceTallyKey(projectID.(string), facility.(string), plan.(string), reservationID.(string)))
+		return checkHardwareReservation(client, projectID.(string), reservationID.(string), plan.(string), facility.(string), count)
+	}
+	// Reserved hardware doesn't depend on the stock in the facility.
+	count := planDevice(client, deviceTallyKey("", facility.(string), plan.(string), ""))
+	return checkDeviceCapacity(client, facility.(string), plan.(string), count)
+}
+
 func resourcePacketDeviceDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
@@ -492,6 +861,68 @@ func powerOnAndWait(d *schema.ResourceData, meta interface{}) error {
 	return err
 }
 
+// setDeviceRescue boots the device into the rescue OS, or reboots it from
+// the rescue OS back to its own, and waits for the transition.
+func setDeviceRescue(d *schema.ResourceData, meta interface{}, rescue bool) error {
+	client := meta.(*packngo.Client)
+
+	if rescue {
+		log.Printf("[DEBUG] Booting device %s into rescue mode", d.Id())
+		if err := deviceAction(client, d.Id(), map[string]interface{}{"type": "rescue"}); err != nil {
+			return err
+		}
+		_, err := waitForDeviceAttribute(d, deviceStateRescue, []string{"active", "rebooting", "provisioning"}, "state", meta)
+		return err
+	}
+
+	log.Printf("[DEBUG] Rebooting device %s out of rescue mode", d.Id())
+	if err := deviceAction(client, d.Id(), map[string]interface{}{"type": "reboot"}); err != nil {
+		return err
+	}
+	_, err := waitForDeviceAttribute(d, "active", []string{deviceStateRescue, "rebooting", "provisioning"}, "state", meta)
+	return err
+}
+
+// reinstallDevice reinstalls the OS of the device with the current
+// operating_system and user_data, keeping the hardware, its IP addresses and
+// attached volumes, and waits for the device to be active again.
+func reinstallDevice(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	if d.HasChange("user_data") {
+		err := updateDevice(client, d.Id(), map[string]interface{}{"userdata": d.Get("user_data").(string)})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[DEBUG] Reinstalling device %s with %s", d.Id(), d.Get("operating_system").(string))
+	action := map[string]interface{}{
+		"type":             "reinstall",
+		"operating_system": d.Get("operating_system").(string),
+		"preserve_data":    d.Get("reinstall.0.preserve_data").(bool),
+		"deprovision_fast": d.Get("reinstall.0.deprovision_fast").(bool),
+	}
+	if err := deviceAction(client, d.Id(), action); err != nil {
+		return err
+	}
+
+	// The device may still be reported active right after the request.
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"active"},
+		Target:     []string{"reinstalling", "queued", "provisioning"},
+		Refresh:    newDeviceStateRefreshFunc(d, "state", meta),
+		Timeout:    10 * time.Minute,
+		Delay:      3 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return err
+	}
+	_, err := waitForDeviceAttribute(d, "active", []string{"reinstalling", "queued", "provisioning"}, "state", meta)
+	return err
+}
+
 func timeValueExample(layout string) string {
 	exampleTime := time.Now().Add(time.Hour + time.Minute).Local()
 
@@ -571,6 +1002,56 @@ func floatAtLeast(min float64) schema.SchemaValidateFunc {
 	}
 }
 
+// flattenCustomData returns the custom data of a device as a JSON string.
+// Devices without custom data have an empty object, which is stored as an
+// empty string so that it matches an unset argument.
+func flattenCustomData(raw *json.RawMessage) string {
+	if raw == nil {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(*raw, &v); err != nil || v == nil {
+		return ""
+	}
+	if m, ok := v.(map[string]interface{}); ok && len(m) == 0 {
+		return ""
+	}
+	return string(*raw)
+}
+
+// validateJSONObject accepts JSON objects only. Other values, including null,
+// are not read back as they were set, so they would never match the config.
+func validateJSONObject(i interface{}, k string) (warns []string, errs []error) {
+	v, ok := i.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &obj); err != nil {
+		errs = append(errs, fmt.Errorf("%s is not a valid JSON object: %s", k, err))
+		return
+	}
+	if obj == nil {
+		errs = append(errs, fmt.Errorf("%s must be a JSON object, got null", k))
+	}
+	return
+}
+
+// suppressEquivalentJSONDiffs suppresses diffs of JSON documents which only
+// differ in formatting or order of keys.
+func suppressEquivalentJSONDiffs(k, old, new string, d *schema.ResourceData) bool {
+	var o, n interface{}
+	if err := json.Unmarshal([]byte(old), &o); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &n); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(o, n)
+}
+
 func stringTimeParsibleBy(parsers *[]timeParserFunc) schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (warns []string, errs []error) {
 		v, ok := i.(string)
@@ -1,6 +1,7 @@
 package packet
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"regexp"
@@ -19,6 +20,7 @@ var matchErrShouldNotBeAnIPXE = regexp.MustCompile(`.*"user_data" should not be
 var matchErrShouldOnlyBeProvided = regexp.MustCompile(".* should only be provided when .*")
 var matchErrOutOfRange = regexp.MustCompile(".* is out of range .*")
 var matchErrIsNotValid = regexp.MustCompile(".* is not a valid value for.*")
+var matchErrNoFreeReservation = regexp.MustCompile(".*no free hardware reservation.*")
 var matchAttrDuration = regexp.MustCompile(`^\dh\d{1,2}m\d{1,2}s$`)
 
 func TestAccPacketDeviceBasic(t *testing.T) {
@@ -51,6 +53,10 @@ func TestAccPacketDeviceBasic(t *testing.T) {
 						r, "spot_price_max", ""),
 					resource.TestCheckResourceAttr(
 						r, "termination_time", ""),
+					resource.TestCheckResourceAttrSet(
+						r, "network.0.id"),
+					resource.TestCheckResourceAttr(
+						r, "network.0.management", "true"),
 				),
 			},
 		},
@@ -309,6 +315,310 @@ func TestAccPacketDeviceSpotInstanceInvalid(t *testing.T) {
 	})
 }
 
+func TestAccPacketDeviceNetworkType(t *testing.T) {
+	var device packngo.Device
+	rs := acctest.RandString(10)
+	r := "packet_device.test_network_type"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketDeviceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigNetworkType, rs, "hybrid"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &device),
+					resource.TestCheckResourceAttr(
+						r, "network_type", "hybrid"),
+					resource.TestCheckResourceAttr(
+						r, "ports.0.name", "bond0"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigNetworkType, rs, "layer3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &device),
+					resource.TestCheckResourceAttr(
+						r, "network_type", "layer3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPacketDevicePrivateOnly(t *testing.T) {
+	var device packngo.Device
+	rs := acctest.RandString(10)
+	r := "packet_device.test_private_only"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketDeviceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigPrivateOnly, rs),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &device),
+					resource.TestCheckResourceAttr(
+						r, "access_public_ipv4", ""),
+					resource.TestCheckResourceAttrSet(
+						r, "access_private_ipv4"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPacketDeviceNoFreeReservation(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketDeviceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckPacketDeviceConfigNextAvailable, rs),
+				ExpectError: matchErrNoFreeReservation,
+			},
+		},
+	})
+}
+
+func TestAccPacketDeviceReinstall(t *testing.T) {
+	var device, reinstalled packngo.Device
+	rs := acctest.RandString(10)
+	r := "packet_device.test_reinstall"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketDeviceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigReinstall, rs, "ubuntu_16_04"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &device),
+					resource.TestCheckResourceAttr(
+						r, "operating_system", "ubuntu_16_04"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigReinstall, rs, "centos_7"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &reinstalled),
+					testAccCheckPacketSameDevice(&device, &reinstalled),
+					resource.TestCheckResourceAttr(
+						r, "operating_system", "centos_7"),
+					resource.TestCheckResourceAttr(
+						r, "state", "active"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPacketSameDevice(before, after *packngo.Device) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID != after.ID {
+			return fmt.Errorf("Device was recreated: %s -> %s", before.ID, after.ID)
+		}
+		return nil
+	}
+}
+
+func TestAccPacketDeviceOSChangeWithoutReinstall(t *testing.T) {
+	var device, replaced packngo.Device
+	rs := acctest.RandString(10)
+	r := "packet_device.test_os_change"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketDeviceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigOSChange, rs, "ubuntu_16_04"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &device),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigOSChange, rs, "centos_7"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &replaced),
+					testAccCheckPacketDeviceReplaced(&device, &replaced),
+					resource.TestCheckResourceAttr(
+						r, "operating_system", "centos_7"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPacketDeviceReplaced(before, after *packngo.Device) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.ID == after.ID {
+			return fmt.Errorf("Device %s was not recreated", before.ID)
+		}
+		return nil
+	}
+}
+
+func TestAccPacketDeviceRescue(t *testing.T) {
+	var device, rescued packngo.Device
+	rs := acctest.RandString(10)
+	r := "packet_device.test_rescue"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketDeviceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigRescue, rs, "false"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &device),
+					resource.TestCheckResourceAttr(
+						r, "state", "active"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigRescue, rs, "true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &rescued),
+					testAccCheckPacketSameDevice(&device, &rescued),
+					resource.TestCheckResourceAttr(
+						r, "state", "rescue"),
+					resource.TestCheckResourceAttrSet(
+						r, "rescue_password"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigRescue, rs, "false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						r, "state", "active"),
+					resource.TestCheckResourceAttr(
+						r, "rescue_password", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPacketDeviceStorage(t *testing.T) {
+	var device packngo.Device
+	rs := acctest.RandString(10)
+	r := "packet_device.test_storage"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketDeviceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigStorage, rs),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &device),
+					resource.TestCheckResourceAttr(
+						r, "state", "active"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPacketDeviceCustomData(t *testing.T) {
+	var device, updated packngo.Device
+	rs := acctest.RandString(10)
+	r := "packet_device.test_custom_data"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketDeviceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigCustomData, rs, "first", `{\"role\": \"web\"}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &device),
+					resource.TestCheckResourceAttr(
+						r, "description", "first"),
+					testAccCheckPacketDeviceCustomData(r, `{"role": "web"}`),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckPacketDeviceConfigCustomData, rs, "second", `{\"role\": \"db\", \"tier\": 2}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPacketDeviceExists(r, &updated),
+					testAccCheckPacketSameDevice(&device, &updated),
+					resource.TestCheckResourceAttr(
+						r, "description", "second"),
+					testAccCheckPacketDeviceCustomData(r, `{"role": "db", "tier": 2}`),
+				),
+			},
+			// Reformatting the document must not cause a diff.
+			resource.TestStep{
+				Config:   fmt.Sprintf(testAccCheckPacketDeviceConfigCustomData, rs, "second", `{\"tier\":2,\"role\":\"db\"}`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// testAccCheckPacketDeviceCustomData checks that custom_data in the state is
+// a JSON document equivalent to the expected one.
+func testAccCheckPacketDeviceCustomData(n, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		got := rs.Primary.Attributes["custom_data"]
+		if !suppressEquivalentJSONDiffs("custom_data", got, expected, nil) {
+			return fmt.Errorf("Expected custom_data %s, got %s", expected, got)
+		}
+		return nil
+	}
+}
+
+func TestFlattenCustomData(t *testing.T) {
+	cases := map[string]string{
+		`{}`:              "",
+		`null`:            "",
+		`{"role": "web"}`: `{"role": "web"}`,
+	}
+	for in, expected := range cases {
+		raw := json.RawMessage(in)
+		if out := flattenCustomData(&raw); out != expected {
+			t.Fatalf("expected %q for %s, got %q", expected, in, out)
+		}
+	}
+	if out := flattenCustomData(nil); out != "" {
+		t.Fatalf("expected empty string for missing custom data, got %q", out)
+	}
+}
+
+func TestValidateJSONObject(t *testing.T) {
+	cases := map[string]bool{
+		`{"role": "web"}`: true,
+		`{}`:              true,
+		`null`:            false,
+		`["web"]`:         false,
+		`"web"`:           false,
+		`{"role":`:        false,
+	}
+	for in, valid := range cases {
+		_, errs := validateJSONObject(in, "custom_data")
+		if valid != (len(errs) == 0) {
+			t.Fatalf("expected valid=%t for %s, got errors %v", valid, in, errs)
+		}
+	}
+}
+
 func testAccCheckPacketDeviceDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*packngo.Client)
 
@@ -507,3 +817,145 @@ resource "packet_device" "test_spot_instance" {
   spot_instance    = %s
   termination_time = "%s"
 }`
+
+var testAccCheckPacketDeviceConfigNetworkType = `
+resource "packet_project" "test" {
+  name = "TerraformTestProject-%s"
+}
+
+resource "packet_device" "test_network_type" {
+  hostname         = "test-network-type"
+  plan             = "baremetal_2"
+  facility         = "sjc1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+  network_type     = "%s"
+}`
+
+var testAccCheckPacketDeviceConfigPrivateOnly = `
+resource "packet_project" "test" {
+  name = "TerraformTestProject-%s"
+}
+
+resource "packet_device" "test_private_only" {
+  hostname         = "test-private-only"
+  plan             = "baremetal_0"
+  facility         = "sjc1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+
+  ip_address {
+    type = "private_ipv4"
+  }
+}`
+
+var testAccCheckPacketDeviceConfigNextAvailable = `
+resource "packet_project" "test" {
+  name = "TerraformTestProject-%s"
+}
+
+resource "packet_device" "test_next_available" {
+  hostname                = "test-next-available"
+  plan                    = "baremetal_0"
+  facility                = "sjc1"
+  operating_system        = "ubuntu_16_04"
+  billing_cycle           = "hourly"
+  project_id              = "${packet_project.test.id}"
+  hardware_reservation_id = "next-available"
+}`
+
+var testAccCheckPacketDeviceConfigReinstall = `
+resource "packet_project" "test" {
+  name = "TerraformTestProject-%s"
+}
+
+resource "packet_device" "test_reinstall" {
+  hostname         = "test-reinstall"
+  plan             = "baremetal_0"
+  facility         = "sjc1"
+  operating_system = "%s"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+
+  reinstall {
+    enabled          = true
+    deprovision_fast = true
+  }
+}`
+
+var testAccCheckPacketDeviceConfigOSChange = `
+resource "packet_project" "test" {
+  name = "TerraformTestProject-%s"
+}
+
+resource "packet_device" "test_os_change" {
+  hostname         = "test-os-change"
+  plan             = "baremetal_0"
+  facility         = "sjc1"
+  operating_system = "%s"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+}`
+
+var testAccCheckPacketDeviceConfigRescue = `
+resource "packet_project" "test" {
+  name = "TerraformTestProject-%s"
+}
+
+resource "packet_device" "test_rescue" {
+  hostname         = "test-rescue"
+  plan             = "baremetal_0"
+  facility         = "sjc1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+  rescue           = %s
+}`
+
+var testAccCheckPacketDeviceConfigStorage = `
+resource "packet_project" "test" {
+  name = "TerraformTestProject-%s"
+}
+
+resource "packet_device" "test_storage" {
+  hostname         = "test-storage"
+  plan             = "baremetal_0"
+  facility         = "sjc1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+
+  storage = <<EOS
+{
+  "disks": [
+    {"device": "/dev/sda", "wipeTable": true, "partitions": [
+      {"label": "BIOS", "number": 1, "size": 4096},
+      {"label": "SWAP", "number": 2, "size": "3993600"},
+      {"label": "ROOT", "number": 3, "size": 0}
+    ]}
+  ],
+  "filesystems": [
+    {"mount": {"device": "/dev/sda3", "format": "ext4", "point": "/", "create": {"options": ["-L", "ROOT"]}}},
+    {"mount": {"device": "/dev/sda2", "format": "swap", "point": "none", "create": {"options": ["-L", "SWAP"]}}}
+  ]
+}
+EOS
+}`
+
+var testAccCheckPacketDeviceConfigCustomData = `
+resource "packet_project" "test" {
+  name = "TerraformTestProject-%s"
+}
+
+resource "packet_device" "test_custom_data" {
+  hostname         = "test-custom-data"
+  plan             = "baremetal_0"
+  facility         = "sjc1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+  description      = "%s"
+  custom_data      = "%s"
+}`
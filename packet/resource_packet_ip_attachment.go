@@ -2,27 +2,66 @@ package packet
 
 import (
 	"fmt"
+	"log"
 	"path"
+	"strings"
+	"sync"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/packethost/packngo"
 )
 
+// reservedBlockLocks serializes picking and assigning of available subnets
+// from the same reserved block, so that attachments created in parallel
+// (e.g. with count) don't pick the same subnet.
+var reservedBlockLocks = struct {
+	sync.Mutex
+	m map[string]*sync.Mutex
+}{m: map[string]*sync.Mutex{}}
+
+func lockReservedBlock(id string) func() {
+	reservedBlockLocks.Lock()
+	l, ok := reservedBlockLocks.m[id]
+	if !ok {
+		l = new(sync.Mutex)
+		reservedBlockLocks.m[id] = l
+	}
+	reservedBlockLocks.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
 func resourcePacketIPAttachment() *schema.Resource {
 	ipAttachmentSchema := packetIPComputedFields()
 	ipAttachmentSchema["device_id"] = &schema.Schema{
 		Type:     schema.TypeString,
-		ForceNew: true,
 		Required: true,
 	}
 	ipAttachmentSchema["cidr_notation"] = &schema.Schema{
-		Type:     schema.TypeString,
-		ForceNew: true,
-		Required: true,
+		Type:          schema.TypeString,
+		ForceNew:      true,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"reserved_block_id"},
+	}
+	ipAttachmentSchema["reserved_block_id"] = &schema.Schema{
+		Type:          schema.TypeString,
+		ForceNew:      true,
+		Optional:      true,
+		ConflictsWith: []string{"cidr_notation"},
+	}
+	ipAttachmentSchema["cidr"] = &schema.Schema{
+		Type:          schema.TypeInt,
+		ForceNew:      true,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"cidr_notation"},
 	}
 	return &schema.Resource{
 		Create: resourcePacketIPAttachmentCreate,
 		Read:   resourcePacketIPAttachmentRead,
+		Update: resourcePacketIPAttachmentUpdate,
 		Delete: resourcePacketIPAttachmentDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -37,6 +76,25 @@ func resourcePacketIPAttachmentCreate(d *schema.ResourceData, meta interface{})
 	deviceID := d.Get("device_id").(string)
 	ipa := d.Get("cidr_notation").(string)
 
+	if blockID, ok := d.GetOk("reserved_block_id"); ok {
+		cidr, ok := d.GetOk("cidr")
+		if !ok {
+			return fmt.Errorf("\"cidr\" must be provided when \"reserved_block_id\" is used")
+		}
+
+		unlock := lockReservedBlock(blockID.(string))
+		defer unlock()
+
+		var err error
+		ipa, err = nextAvailableSubnet(client, blockID.(string), cidr.(int))
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Picked subnet %s from reserved block %s", ipa, blockID)
+	} else if ipa == "" {
+		return fmt.Errorf("one of \"cidr_notation\" or \"reserved_block_id\" must be provided")
+	}
+
 	req := packngo.AddressStruct{Address: ipa}
 
 	assignment, _, err := client.DeviceIPs.Assign(deviceID, &req)
@@ -49,11 +107,51 @@ func resourcePacketIPAttachmentCreate(d *schema.ResourceData, meta interface{})
 	return resourcePacketIPAttachmentRead(d, meta)
 }
 
+// availableSubnets lists unassigned subnets with the given prefix length from
+// a reserved block, in CIDR notation.
+func availableSubnets(client *packngo.Client, blockID string, cidr int) ([]string, error) {
+	available, _, err := client.ProjectIPs.AvailableAddresses(blockID, &packngo.AvailableRequest{CIDR: cidr})
+	if err != nil {
+		return nil, fmt.Errorf("error listing available addresses in block %s: %s", blockID, friendlyError(err))
+	}
+
+	subnets := make([]string, 0, len(available))
+	for _, a := range available {
+		if !strings.Contains(a, "/") {
+			a = fmt.Sprintf("%s/%d", a, cidr)
+		}
+		subnets = append(subnets, a)
+	}
+	return subnets, nil
+}
+
+// nextAvailableSubnet returns the first unassigned subnet with the given prefix
+// length from a reserved block, in CIDR notation.
+func nextAvailableSubnet(client *packngo.Client, blockID string, cidr int) (string, error) {
+	available, err := availableSubnets(client, blockID, cidr)
+	if err != nil {
+		return "", err
+	}
+	if len(available) == 0 {
+		return "", fmt.Errorf("no /%d subnet is available in reserved block %s", cidr, blockID)
+	}
+	return available[0], nil
+}
+
 func resourcePacketIPAttachmentRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 	assignment, _, err := client.DeviceIPs.Get(d.Id())
 	if err != nil {
-		return fmt.Errorf("can't read assignment %v: %s", d, err)
+		err = friendlyError(err)
+
+		// If the assignment or the device is somehow already destroyed,
+		// mark as succesfully gone.
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
 	}
 
 	d.SetId(assignment.ID)
@@ -74,6 +172,44 @@ func resourcePacketIPAttachmentRead(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
+// resourcePacketIPAttachmentUpdate moves the subnet to another device. The
+// subnet is assigned to the new device before it's unassigned from the old
+// one, so that there is no period in which it's not routed anywhere.
+func resourcePacketIPAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	if d.HasChange("device_id") {
+		deviceID := d.Get("device_id").(string)
+		ipa := d.Get("cidr_notation").(string)
+		oldID := d.Id()
+
+		// If removing the old assignment fails, the state must already
+		// point to the new one.
+		d.Partial(true)
+
+		log.Printf("[DEBUG] Moving address %s to device %s", ipa, deviceID)
+		req := packngo.AddressStruct{Address: ipa}
+		assignment, _, err := client.DeviceIPs.Assign(deviceID, &req)
+		if err != nil {
+			return fmt.Errorf("error assigning address %s to device %s: %s", ipa, deviceID, friendlyError(err))
+		}
+		d.SetId(assignment.ID)
+		d.SetPartial("device_id")
+
+		_, err = client.DeviceIPs.Unassign(oldID)
+		if err != nil {
+			err = friendlyError(err)
+			if !isNotFound(err) {
+				return fmt.Errorf("error removing previous assignment %s of address %s: %s", oldID, ipa, err)
+			}
+		}
+
+		d.Partial(false)
+	}
+
+	return resourcePacketIPAttachmentRead(d, meta)
+}
+
 func resourcePacketIPAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
@@ -82,7 +218,10 @@ func resourcePacketIPAttachmentDelete(d *schema.ResourceData, meta interface{})
 	_, err := client.DeviceIPs.Unassign(id)
 
 	if err != nil {
-		return fmt.Errorf("Error removing assingment %v: %s", d, err)
+		err = friendlyError(err)
+		if !isNotFound(err) {
+			return err
+		}
 	}
 
 	d.SetId("")
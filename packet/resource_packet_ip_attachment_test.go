@@ -38,6 +38,82 @@ func TestAccPacketIPAttachmentBasic(t *testing.T) {
 	})
 }
 
+func TestAccPacketIPAttachmentGlobal(t *testing.T) {
+
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketIPAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketIPAttachmentConfigGlobal(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_ip_attachment.test", "cidr", "32"),
+					resource.TestCheckResourceAttrPair(
+						"packet_ip_attachment.test", "device_id",
+						"packet_device.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPacketIPAttachmentReservedBlock(t *testing.T) {
+
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketIPAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketIPAttachmentConfigReservedBlock(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_ip_attachment.test.0", "cidr", "32"),
+					resource.TestCheckResourceAttr(
+						"packet_ip_attachment.test.1", "cidr", "32"),
+					resource.TestCheckResourceAttrSet(
+						"packet_ip_attachment.test.0", "cidr_notation"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPacketIPAttachmentMove(t *testing.T) {
+
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketIPAttachmentDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketIPAttachmentConfigMove(rs, "primary"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"packet_ip_attachment.test", "device_id",
+						"packet_device.primary", "id"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckPacketIPAttachmentConfigMove(rs, "secondary"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"packet_ip_attachment.test", "device_id",
+						"packet_device.secondary", "id"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckPacketIPAttachmentDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*packngo.Client)
 
@@ -80,3 +156,95 @@ resource "packet_ip_attachment" "test" {
 	cidr_notation = "${cidrhost(packet_reserved_ip_block.test.cidr_notation,0)}/32"
 }`, name)
 }
+
+func testAccCheckPacketIPAttachmentConfigGlobal(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "%s"
+}
+
+resource "packet_device" "test" {
+  hostname         = "test"
+  plan             = "baremetal_0"
+  facility         = "sjc1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+}
+
+resource "packet_reserved_ip_block" "test" {
+    project_id = "${packet_project.test.id}"
+    type = "global_ipv4"
+    quantity = 1
+}
+
+resource "packet_ip_attachment" "test" {
+	device_id = "${packet_device.test.id}"
+	cidr_notation = "${packet_reserved_ip_block.test.cidr_notation}"
+}`, name)
+}
+
+func testAccCheckPacketIPAttachmentConfigReservedBlock(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "%s"
+}
+
+resource "packet_device" "test" {
+  hostname         = "test"
+  plan             = "baremetal_0"
+  facility         = "ewr1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+}
+
+resource "packet_reserved_ip_block" "test" {
+    project_id = "${packet_project.test.id}"
+    facility = "ewr1"
+	quantity = 2
+}
+
+resource "packet_ip_attachment" "test" {
+	count = 2
+	device_id = "${packet_device.test.id}"
+	reserved_block_id = "${packet_reserved_ip_block.test.id}"
+	cidr = 32
+}`, name)
+}
+
+func testAccCheckPacketIPAttachmentConfigMove(name, device string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "%s"
+}
+
+resource "packet_device" "primary" {
+  hostname         = "primary"
+  plan             = "baremetal_0"
+  facility         = "ewr1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+}
+
+resource "packet_device" "secondary" {
+  hostname         = "secondary"
+  plan             = "baremetal_0"
+  facility         = "ewr1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+}
+
+resource "packet_reserved_ip_block" "test" {
+    project_id = "${packet_project.test.id}"
+    facility = "ewr1"
+	quantity = 1
+}
+
+resource "packet_ip_attachment" "test" {
+	device_id = "${packet_device.%s.id}"
+	cidr_notation = "${packet_reserved_ip_block.test.cidr_notation}"
+}`, name, device)
+}
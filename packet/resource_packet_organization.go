@@ -0,0 +1,145 @@
+package packet
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func organizationFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"website": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"twitter": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"logo": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"created": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"updated": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func resourcePacketOrganization() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePacketOrganizationCreate,
+		Read:   resourcePacketOrganizationRead,
+		Update: resourcePacketOrganizationUpdate,
+		Delete: resourcePacketOrganizationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: organizationFields(),
+	}
+}
+
+func loadOrganization(d *schema.ResourceData, org *packngo.Organization) {
+	d.SetId(org.ID)
+	d.Set("name", org.Name)
+	d.Set("description", org.Description)
+	d.Set("website", org.Website)
+	d.Set("twitter", org.Twitter)
+	d.Set("logo", org.Logo)
+	d.Set("created", org.Created)
+	d.Set("updated", org.Updated)
+}
+
+func resourcePacketOrganizationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	createRequest := &packngo.OrganizationCreateRequest{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Website:     d.Get("website").(string),
+		Twitter:     d.Get("twitter").(string),
+		Logo:        d.Get("logo").(string),
+	}
+
+	org, _, err := client.Organizations.Create(createRequest)
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	d.SetId(org.ID)
+
+	return resourcePacketOrganizationRead(d, meta)
+}
+
+func resourcePacketOrganizationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	org, _, err := client.Organizations.Get(d.Id())
+	if err != nil {
+		err = friendlyError(err)
+
+		// If the organization somehow already destroyed, mark as succesfully gone.
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	loadOrganization(d, org)
+
+	return nil
+}
+
+func resourcePacketOrganizationUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	name := d.Get("name").(string)
+	description := d.Get("description").(string)
+	website := d.Get("website").(string)
+	twitter := d.Get("twitter").(string)
+	logo := d.Get("logo").(string)
+	updateRequest := &packngo.OrganizationUpdateRequest{
+		Name:        &name,
+		Description: &description,
+		Website:     &website,
+		Twitter:     &twitter,
+		Logo:        &logo,
+	}
+
+	_, _, err := client.Organizations.Update(d.Id(), updateRequest)
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	return resourcePacketOrganizationRead(d, meta)
+}
+
+func resourcePacketOrganizationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	_, err := client.Organizations.Delete(d.Id())
+	if err != nil {
+		err = friendlyError(err)
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
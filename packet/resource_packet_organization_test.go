@@ -0,0 +1,76 @@
+package packet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/packethost/packngo"
+)
+
+func TestAccPacketOrganizationBasic(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketOrganizationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketOrganizationConfigBasic(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_organization.test", "name", fmt.Sprintf("tfacc-%s", rs)),
+					resource.TestCheckResourceAttr(
+						"packet_organization.test", "website", "https://example.com"),
+					resource.TestCheckResourceAttrPair(
+						"packet_project.test", "organization_id",
+						"packet_organization.test", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.packet_organization.test", "id",
+						"packet_organization.test", "id"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "packet_organization.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPacketOrganizationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*packngo.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "packet_organization" {
+			continue
+		}
+		if _, _, err := client.Organizations.Get(rs.Primary.ID); err == nil {
+			return fmt.Errorf("Organization still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPacketOrganizationConfigBasic(name string) string {
+	return fmt.Sprintf(`
+resource "packet_organization" "test" {
+    name        = "tfacc-%s"
+    description = "tftest"
+    website     = "https://example.com"
+}
+
+resource "packet_project" "test" {
+    name            = "tfacc-%s"
+    organization_id = "${packet_organization.test.id}"
+}
+
+data "packet_organization" "test" {
+    organization_id = "${packet_organization.test.id}"
+}`, name, name)
+}
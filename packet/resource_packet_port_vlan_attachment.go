@@ -0,0 +1,222 @@
+package packet
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+func resourcePacketPortVlanAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePacketPortVlanAttachmentCreate,
+		Read:   resourcePacketPortVlanAttachmentRead,
+		Update: resourcePacketPortVlanAttachmentUpdate,
+		Delete: resourcePacketPortVlanAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePacketPortVlanAttachmentImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"port_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vlan_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"native": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"port_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePacketPortVlanAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	deviceID := d.Get("device_id").(string)
+	portName := d.Get("port_name").(string)
+	vlanID := d.Get("vlan_id").(string)
+
+	device, err := getDevice(client, deviceID)
+	if err != nil {
+		return friendlyError(err)
+	}
+	port := device.port(portName)
+	if port == nil {
+		return fmt.Errorf("device %s has no port named %q", deviceID, portName)
+	}
+
+	if !port.hasVirtualNetwork(vlanID) {
+		log.Printf("[DEBUG] Assigning VLAN %s to port %s of device %s", vlanID, portName, deviceID)
+		_, _, err = client.DevicePorts.Assign(&packngo.PortAssignRequest{PortID: port.ID, VirtualNetworkID: vlanID})
+		if err != nil {
+			return friendlyError(err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", deviceID, portName, vlanID))
+
+	if err := waitForPortVlanAssignment(client, deviceID, portName, vlanID, true); err != nil {
+		return err
+	}
+
+	if d.Get("native").(bool) {
+		if err := setPortNativeVlan(client, port.ID, vlanID); err != nil {
+			return err
+		}
+	}
+
+	return resourcePacketPortVlanAttachmentRead(d, meta)
+}
+
+func resourcePacketPortVlanAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	deviceID := d.Get("device_id").(string)
+	portName := d.Get("port_name").(string)
+	vlanID := d.Get("vlan_id").(string)
+
+	device, err := getDevice(client, deviceID)
+	if err != nil {
+		err = friendlyError(err)
+
+		// If the device somehow already destroyed, the attachment is gone too.
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	port := device.port(portName)
+	if port == nil || !port.hasVirtualNetwork(vlanID) {
+		log.Printf("[WARN] VLAN %s is not assigned to port %s of device %s", vlanID, portName, deviceID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("port_id", port.ID)
+	d.Set("native", port.NativeVirtualNetwork != nil && path.Base(port.NativeVirtualNetwork.Href) == vlanID)
+
+	return nil
+}
+
+func resourcePacketPortVlanAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	if d.HasChange("native") {
+		portID := d.Get("port_id").(string)
+		var err error
+		if d.Get("native").(bool) {
+			err = setPortNativeVlan(client, portID, d.Get("vlan_id").(string))
+		} else {
+			err = removePortNativeVlan(client, portID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourcePacketPortVlanAttachmentRead(d, meta)
+}
+
+func resourcePacketPortVlanAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	deviceID := d.Get("device_id").(string)
+	portName := d.Get("port_name").(string)
+	portID := d.Get("port_id").(string)
+	vlanID := d.Get("vlan_id").(string)
+
+	// The native VLAN must be unset before it can be unassigned from the port.
+	if d.Get("native").(bool) {
+		if err := removePortNativeVlan(client, portID); err != nil {
+			if !isNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	_, _, err := client.DevicePorts.Unassign(&packngo.PortAssignRequest{PortID: portID, VirtualNetworkID: vlanID})
+	if err != nil {
+		err = friendlyError(err)
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return waitForPortVlanAssignment(client, deviceID, portName, vlanID, false)
+}
+
+// resourcePacketPortVlanAttachmentImport imports attachment by its ID, which
+// is in form "<device_id>:<port_name>:<vlan_id>".
+func resourcePacketPortVlanAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("import ID must be in form \"<device_id>:<port_name>:<vlan_id>\", got %q", d.Id())
+	}
+
+	d.Set("device_id", parts[0])
+	d.Set("port_name", parts[1])
+	d.Set("vlan_id", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForPortVlanAssignment waits until the VLAN shows up in (or disappears
+// from) the port's virtual networks. Port assignments are asynchronous.
+func waitForPortVlanAssignment(client *packngo.Client, deviceID, portName, vlanID string, assigned bool) error {
+	target, pending := "assigned", "unassigned"
+	if !assigned {
+		target, pending = pending, target
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{pending},
+		Target:  []string{target},
+		Refresh: func() (interface{}, string, error) {
+			device, err := getDevice(client, deviceID)
+			if err != nil {
+				err = friendlyError(err)
+				if !assigned && isNotFound(err) {
+					return deviceID, "unassigned", nil
+				}
+				return nil, "", err
+			}
+			port := device.port(portName)
+			if port != nil && port.hasVirtualNetwork(vlanID) {
+				return port, "assigned", nil
+			}
+			return device, "unassigned", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      3 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
@@ -0,0 +1,75 @@
+package packet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccPacketPortVlanAttachmentBasic(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketVlanDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketPortVlanAttachmentConfigBasic(rs, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"packet_port_vlan_attachment.test", "vlan_id",
+						"packet_vlan.test", "id"),
+					resource.TestCheckResourceAttrSet(
+						"packet_port_vlan_attachment.test", "port_id"),
+					resource.TestCheckResourceAttr(
+						"packet_port_vlan_attachment.test", "native", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckPacketPortVlanAttachmentConfigBasic(rs, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_port_vlan_attachment.test", "native", "true"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "packet_port_vlan_attachment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPacketPortVlanAttachmentConfigBasic(name string, native bool) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "%s"
+}
+
+resource "packet_device" "test" {
+  hostname         = "tftest-vlan"
+  plan             = "baremetal_2"
+  facility         = "ewr1"
+  operating_system = "ubuntu_16_04"
+  billing_cycle    = "hourly"
+  project_id       = "${packet_project.test.id}"
+  network_type     = "hybrid"
+}
+
+resource "packet_vlan" "test" {
+    project_id  = "${packet_project.test.id}"
+    facility    = "ewr1"
+    description = "tftest"
+}
+
+resource "packet_port_vlan_attachment" "test" {
+    device_id = "${packet_device.test.id}"
+    port_name = "eth1"
+    vlan_id   = "${packet_vlan.test.id}"
+    native    = %t
+}`, name, native)
+}
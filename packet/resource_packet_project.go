@@ -1,6 +1,11 @@
 package packet
 
 import (
+	"fmt"
+	"log"
+	"path"
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/packethost/packngo"
 )
@@ -12,6 +17,8 @@ func resourcePacketProject() *schema.Resource {
 		Update: resourcePacketProjectUpdate,
 		Delete: resourcePacketProjectDelete,
 
+		CustomizeDiff: resourcePacketProjectCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -21,8 +28,18 @@ func resourcePacketProject() *schema.Resource {
 			"payment_method": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
+			},
+
+			"organization_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 
+			"bgp_config": bgpConfigSchema(),
+
 			"created": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -39,9 +56,14 @@ func resourcePacketProject() *schema.Resource {
 func resourcePacketProjectCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
+	if err := validatePaymentMethod(client, d.Get("payment_method").(string)); err != nil {
+		return err
+	}
+
 	createRequest := &packngo.ProjectCreateRequest{
-		Name:          d.Get("name").(string),
-		PaymentMethod: d.Get("payment_method").(string),
+		Name:            d.Get("name").(string),
+		PaymentMethodID: d.Get("payment_method").(string),
+		OrganizationID:  d.Get("organization_id").(string),
 	}
 
 	project, _, err := client.Projects.Create(createRequest)
@@ -51,6 +73,12 @@ func resourcePacketProjectCreate(d *schema.ResourceData, meta interface{}) error
 
 	d.SetId(project.ID)
 
+	if c := expandBGPConfig(d); c != nil {
+		if err := createBGPConfig(client, project.ID, c); err != nil {
+			return err
+		}
+	}
+
 	return resourcePacketProjectRead(d, meta)
 }
 
@@ -73,6 +101,24 @@ func resourcePacketProjectRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("id", key.ID)
 	d.Set("name", key.Name)
+	// The API links the payment method by its href, which ends with the ID.
+	if key.PaymentMethod.URL != "" {
+		d.Set("payment_method", path.Base(key.PaymentMethod.URL))
+	}
+	if key.Organization.URL != "" {
+		d.Set("organization_id", path.Base(key.Organization.URL))
+	}
+	bgp, err := getBGPConfig(client, d.Id())
+	switch {
+	case isForbidden(err):
+		// Users without access to the BGP config can still manage the
+		// other attributes of the project.
+		log.Printf("[WARN] Not allowed to read BGP config of project %s, keeping it as is", d.Id())
+	case err != nil:
+		return err
+	default:
+		d.Set("bgp_config", flattenBGPConfig(d, bgp))
+	}
 	d.Set("created", key.Created)
 	d.Set("updated", key.Updated)
 
@@ -82,16 +128,31 @@ func resourcePacketProjectRead(d *schema.ResourceData, meta interface{}) error {
 func resourcePacketProjectUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
+	// BGP config can only be added, which resourcePacketProjectCustomizeDiff
+	// checks in the plan. It's done first, so that nothing is left half
+	// updated if it fails.
+	if c := expandBGPConfig(d); c != nil && d.HasChange("bgp_config") {
+		if err := createBGPConfig(client, d.Id(), c); err != nil {
+			return err
+		}
+	}
+
+	name := d.Get("name").(string)
 	updateRequest := &packngo.ProjectUpdateRequest{
-		ID:   d.Get("id").(string),
-		Name: d.Get("name").(string),
+		Name: &name,
 	}
 
 	if attr, ok := d.GetOk("payment_method"); ok {
-		updateRequest.PaymentMethod = attr.(string)
+		paymentMethod := attr.(string)
+		updateRequest.PaymentMethodID = &paymentMethod
+		if d.HasChange("payment_method") {
+			if err := validatePaymentMethod(client, paymentMethod); err != nil {
+				return err
+			}
+		}
 	}
 
-	_, _, err := client.Projects.Update(updateRequest)
+	_, _, err := client.Projects.Update(d.Id(), updateRequest)
 	if err != nil {
 		return friendlyError(err)
 	}
@@ -99,6 +160,16 @@ func resourcePacketProjectUpdate(d *schema.ResourceData, meta interface{}) error
 	return resourcePacketProjectRead(d, meta)
 }
 
+// resourcePacketProjectCustomizeDiff rejects changes of an existing BGP
+// config in the plan, as the API only allows adding it.
+func resourcePacketProjectCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	o, _ := d.GetChange("bgp_config")
+	if len(o.([]interface{})) > 0 && d.HasChange("bgp_config") {
+		return fmt.Errorf("BGP config of a project can't be changed, please contact Packet support")
+	}
+	return nil
+}
+
 func resourcePacketProjectDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
@@ -110,3 +181,24 @@ func resourcePacketProjectDelete(d *schema.ResourceData, meta interface{}) error
 	d.SetId("")
 	return nil
 }
+
+// validatePaymentMethod checks that the payment method exists. An expired
+// card is only logged, the API decides whether it can still be used.
+func validatePaymentMethod(client *packngo.Client, id string) error {
+	if id == "" {
+		return nil
+	}
+
+	pm, err := getPaymentMethod(client, id)
+	if err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("Payment method %s was not found", id)
+		}
+		return err
+	}
+	if pm.expired(time.Now()) {
+		log.Printf("[WARN] Payment method %s (%s) expired in %s/%s", id, pm.Name, pm.ExpirationMonth, pm.ExpirationYear)
+	}
+
+	return nil
+}
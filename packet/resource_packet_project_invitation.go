@@ -0,0 +1,180 @@
+package packet
+
+import (
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+// projectInvitation is an invitation of a user, identified by email, to
+// a project.
+type projectInvitation struct {
+	ID        string        `json:"id"`
+	Invitee   string        `json:"invitee"`
+	Roles     []string      `json:"roles"`
+	Project   *packngo.Href `json:"project,omitempty"`
+	InvitedBy *packngo.Href `json:"invited_by,omitempty"`
+	Created   string        `json:"created_at,omitempty"`
+	Href      string        `json:"href,omitempty"`
+}
+
+type projectInvitationRequest struct {
+	Invitee string   `json:"invitee"`
+	Roles   []string `json:"roles"`
+}
+
+func resourcePacketProjectInvitation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePacketProjectInvitationCreate,
+		Read:   resourcePacketProjectInvitationRead,
+		Delete: resourcePacketProjectInvitationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressEmailCaseDiff,
+			},
+
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: stringInValues(projectRoles),
+			},
+
+			"accepted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"invited_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePacketProjectInvitationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	projectID := d.Get("project_id").(string)
+
+	req := &projectInvitationRequest{
+		Invitee: d.Get("email").(string),
+		Roles:   []string{d.Get("role").(string)},
+	}
+
+	invitation, err := createProjectInvitation(client, projectID, req)
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	d.SetId(invitation.ID)
+
+	return resourcePacketProjectInvitationRead(d, meta)
+}
+
+func resourcePacketProjectInvitationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	invitation, err := getProjectInvitation(client, d.Id())
+	if err != nil {
+		err = friendlyError(err)
+		if !isNotFound(err) {
+			return err
+		}
+
+		// Accepted invitations disappear from the API. The invitation is
+		// only gone for good if the invitee is not a member of the project.
+		accepted, err := isProjectMember(client, d.Get("project_id").(string), d.Get("email").(string))
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			d.SetId("")
+			return nil
+		}
+
+		d.Set("accepted", true)
+		return nil
+	}
+
+	if invitation.Project != nil {
+		d.Set("project_id", path.Base(invitation.Project.Href))
+	}
+	d.Set("email", invitation.Invitee)
+	if len(invitation.Roles) > 0 {
+		d.Set("role", invitation.Roles[0])
+	}
+	if invitation.InvitedBy != nil {
+		d.Set("invited_by", path.Base(invitation.InvitedBy.Href))
+	}
+	d.Set("accepted", false)
+	d.Set("created", invitation.Created)
+
+	return nil
+}
+
+func isProjectMember(client *packngo.Client, projectID, email string) (bool, error) {
+	if projectID == "" || email == "" {
+		return false, nil
+	}
+
+	memberships, err := listProjectMemberships(client, projectID)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, m := range memberships {
+		if m.User == nil {
+			continue
+		}
+		if strings.EqualFold(m.User.Email, email) {
+			return true, nil
+		}
+		for _, e := range m.User.Emails {
+			if strings.EqualFold(e.Address, email) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func resourcePacketProjectInvitationDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	if !d.Get("accepted").(bool) {
+		err := deleteProjectInvitation(client, d.Id())
+		if err != nil {
+			err = friendlyError(err)
+			if !isNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
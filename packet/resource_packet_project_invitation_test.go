@@ -0,0 +1,67 @@
+package packet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/packethost/packngo"
+)
+
+func TestAccPacketProjectInvitationBasic(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketProjectInvitationDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketProjectInvitationConfigBasic(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_project_invitation.test", "email", fmt.Sprintf("tfacc-%s@example.com", rs)),
+					resource.TestCheckResourceAttr(
+						"packet_project_invitation.test", "role", "collaborator"),
+					resource.TestCheckResourceAttr(
+						"packet_project_invitation.test", "accepted", "false"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "packet_project_invitation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPacketProjectInvitationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*packngo.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "packet_project_invitation" {
+			continue
+		}
+		if _, err := getProjectInvitation(client, rs.Primary.ID); err == nil {
+			return fmt.Errorf("Invitation still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPacketProjectInvitationConfigBasic(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "tfacc-%s"
+}
+
+resource "packet_project_invitation" "test" {
+    project_id = "${packet_project.test.id}"
+    email      = "tfacc-%s@example.com"
+    role       = "collaborator"
+}`, name, name)
+}
@@ -0,0 +1,182 @@
+package packet
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+var projectRoles = []string{"admin", "collaborator", "limited_collaborator", "billing"}
+
+// projectMembership is a user's membership in a project, with the user's
+// roles.
+type projectMembership struct {
+	ID      string        `json:"id"`
+	Roles   []string      `json:"roles"`
+	User    *packngo.User `json:"user,omitempty"`
+	Project *packngo.Href `json:"project,omitempty"`
+	Created string        `json:"created_at,omitempty"`
+	Updated string        `json:"updated_at,omitempty"`
+	Href    string        `json:"href,omitempty"`
+}
+
+type projectMembershipRequest struct {
+	UserID string   `json:"user_id,omitempty"`
+	Email  string   `json:"email,omitempty"`
+	Roles  []string `json:"roles"`
+}
+
+func suppressEmailCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+func resourcePacketProjectMember() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePacketProjectMemberCreate,
+		Read:   resourcePacketProjectMemberRead,
+		Update: resourcePacketProjectMemberUpdate,
+		Delete: resourcePacketProjectMemberDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"user_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"email"},
+			},
+
+			"email": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"user_id"},
+				DiffSuppressFunc: suppressEmailCaseDiff,
+			},
+
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: stringInValues(projectRoles),
+			},
+
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"updated": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePacketProjectMemberCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	projectID := d.Get("project_id").(string)
+
+	req := &projectMembershipRequest{
+		UserID: d.Get("user_id").(string),
+		Email:  d.Get("email").(string),
+		Roles:  []string{d.Get("role").(string)},
+	}
+	if req.UserID == "" && req.Email == "" {
+		return fmt.Errorf("one of \"user_id\" or \"email\" must be provided")
+	}
+
+	membership, err := createProjectMembership(client, projectID, req)
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	d.SetId(membership.ID)
+
+	return resourcePacketProjectMemberRead(d, meta)
+}
+
+func resourcePacketProjectMemberRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	membership, err := getProjectMembership(client, d.Id())
+	if err != nil {
+		err = friendlyError(err)
+
+		// If the user was removed from the project out of band, mark as succesfully gone.
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if membership.Project != nil {
+		d.Set("project_id", path.Base(membership.Project.Href))
+	}
+	if membership.User != nil {
+		userID := membership.User.ID
+		if userID == "" {
+			userID = path.Base(membership.User.URL)
+		}
+		d.Set("user_id", userID)
+		if membership.User.Email != "" {
+			d.Set("email", membership.User.Email)
+		}
+	}
+	if len(membership.Roles) > 0 {
+		d.Set("role", membership.Roles[0])
+	}
+	d.Set("href", membership.Href)
+	d.Set("created", membership.Created)
+	d.Set("updated", membership.Updated)
+
+	return nil
+}
+
+func resourcePacketProjectMemberUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	if d.HasChange("role") {
+		req := &projectMembershipRequest{Roles: []string{d.Get("role").(string)}}
+		if err := updateProjectMembership(client, d.Id(), req); err != nil {
+			return friendlyError(err)
+		}
+	}
+
+	return resourcePacketProjectMemberRead(d, meta)
+}
+
+func resourcePacketProjectMemberDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	err := deleteProjectMembership(client, d.Id())
+	if err != nil {
+		err = friendlyError(err)
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,79 @@
+package packet
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/packethost/packngo"
+)
+
+func TestAccPacketProjectMemberBasic(t *testing.T) {
+	// The member must be an existing Packet user other than the owner of
+	// PACKET_AUTH_TOKEN.
+	email := os.Getenv("PACKET_TEST_MEMBER_EMAIL")
+	if email == "" {
+		t.Skip("PACKET_TEST_MEMBER_EMAIL must be set for project member acceptance tests")
+	}
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketProjectMemberDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketProjectMemberConfigBasic(rs, email, "collaborator"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_project_member.test", "role", "collaborator"),
+					resource.TestCheckResourceAttrSet(
+						"packet_project_member.test", "user_id"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckPacketProjectMemberConfigBasic(rs, email, "admin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_project_member.test", "role", "admin"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "packet_project_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPacketProjectMemberDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*packngo.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "packet_project_member" {
+			continue
+		}
+		if _, err := getProjectMembership(client, rs.Primary.ID); err == nil {
+			return fmt.Errorf("Project membership still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPacketProjectMemberConfigBasic(name, email, role string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "tfacc-%s"
+}
+
+resource "packet_project_member" "test" {
+    project_id = "${packet_project.test.id}"
+    email      = "%s"
+    role       = "%s"
+}`, name, email, role)
+}
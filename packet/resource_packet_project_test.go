@@ -2,8 +2,10 @@ package packet
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/packethost/packngo"
@@ -30,6 +32,30 @@ func TestAccPacketProjectBasic(t *testing.T) {
 	})
 }
 
+func TestAccPacketProjectBGPConfigChange(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketProjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketProjectConfigBGP(rs, 65000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_project.foobar", "bgp_config.0.asn", "65000"),
+				),
+			},
+			resource.TestStep{
+				Config:      testAccCheckPacketProjectConfigBGP(rs, 65001),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("BGP config of a project can't be changed"),
+			},
+		},
+	})
+}
+
 func testAccCheckPacketProjectDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*packngo.Client)
 
@@ -84,3 +110,14 @@ var testAccCheckPacketProjectConfigBasic = fmt.Sprintf(`
 resource "packet_project" "foobar" {
     name = "foobar"
 }`)
+
+func testAccCheckPacketProjectConfigBGP(name string, asn int) string {
+	return fmt.Sprintf(`
+resource "packet_project" "foobar" {
+    name = "%s"
+    bgp_config {
+        deployment_type = "local"
+        asn             = %d
+    }
+}`, name, asn)
+}
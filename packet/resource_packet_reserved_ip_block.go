@@ -49,6 +49,26 @@ func packetIPComputedFields() map[string]*schema.Schema {
 	}
 }
 
+// reservedIPBlockTypes are the types of IP blocks which can be reserved.
+// IPv6 blocks are allocated by Packet with the first device in a facility,
+// they can only be imported.
+var reservedIPBlockTypes = []string{"public_ipv4", "global_ipv4", "private_ipv4"}
+
+// packetIPReservation adds the global flag to packngo.IPAddressReservation,
+// and the assignments as they are returned when included in the request.
+type packetIPReservation struct {
+	packngo.IPAddressReservation
+	Global      bool                          `json:"global_ip"`
+	Assignments []packngo.IPAddressAssignment `json:"assignments"`
+}
+
+// globalIPReservationRequest is a reservation request without facility.
+type globalIPReservationRequest struct {
+	Type     string `json:"type"`
+	Quantity int    `json:"quantity"`
+	Comments string `json:"comments,omitempty"`
+}
+
 func resourcePacketReservedIPBlock() *schema.Resource {
 	reservedBlockSchema := packetIPComputedFields()
 	reservedBlockSchema["project_id"] = &schema.Schema{
@@ -58,12 +78,27 @@ func resourcePacketReservedIPBlock() *schema.Resource {
 	}
 	reservedBlockSchema["facility"] = &schema.Schema{
 		Type:     schema.TypeString,
-		Required: true,
+		Optional: true,
 		ForceNew: true,
 	}
 	reservedBlockSchema["quantity"] = &schema.Schema{
-		Type:     schema.TypeInt,
-		Required: true,
+		Type:         schema.TypeInt,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: intPowerOfTwoBetween(1, 256),
+	}
+	// Without a default, imported blocks of other types are not replaced
+	// when type is omitted in the config. Create falls back to public_ipv4.
+	reservedBlockSchema["type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: stringInValues(reservedIPBlockTypes),
+	}
+	reservedBlockSchema["comments"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
 		ForceNew: true,
 	}
 	reservedBlockSchema["cidr_notation"] = &schema.Schema{
@@ -87,21 +122,45 @@ func resourcePacketReservedIPBlockCreate(d *schema.ResourceData, meta interface{
 	client := meta.(*packngo.Client)
 	facility := d.Get("facility").(string)
 	quantity := d.Get("quantity").(int)
+	blockType := d.Get("type").(string)
+	projectID := d.Get("project_id").(string)
 
-	req := packngo.IPReservationRequest{
-		Type:     "public_ipv4",
-		Quantity: quantity,
-		Facility: facility,
+	if blockType == "" {
+		blockType = "public_ipv4"
 	}
 
-	projectID := d.Get("project_id").(string)
-
-	blockAddr, _, err := client.ProjectIPs.Request(projectID, &req)
+	var (
+		blockAddr *packngo.IPAddressReservation
+		err       error
+	)
+	if blockType == "global_ipv4" {
+		if facility != "" {
+			return fmt.Errorf("\"facility\" must not be provided for \"global_ipv4\" blocks")
+		}
+		req := globalIPReservationRequest{
+			Type:     blockType,
+			Quantity: quantity,
+			Comments: d.Get("comments").(string),
+		}
+		blockAddr, err = requestGlobalIPReservation(client, projectID, &req)
+	} else {
+		if facility == "" {
+			return fmt.Errorf("\"facility\" must be provided for %q blocks", blockType)
+		}
+		req := packngo.IPReservationRequest{
+			Type:     blockType,
+			Quantity: quantity,
+			Facility: facility,
+			Comments: d.Get("comments").(string),
+		}
+		blockAddr, _, err = client.ProjectIPs.Request(projectID, &req)
+	}
 	if err != nil {
 		return fmt.Errorf("Error reserving IP address block: %s", err)
 	}
 
 	d.Set("facility", facility)
+	d.Set("type", blockType)
 	d.Set("quantity", quantity)
 	d.Set("project_id", projectID)
 	d.SetId(blockAddr.ID)
@@ -126,10 +185,10 @@ func loadBlock(d *schema.ResourceData, reservedBlock *packngo.IPAddressReservati
 	if reservedBlock.AddressFamily == 4 {
 		d.Set("quantity", ipv4CIDRToQuantity[reservedBlock.CIDR])
 	} else {
-		// In Packet, reserved IPv6 block is allocated when device is run in a proejct.
-		// It's always /56, and it can't be crated with Terraform, only imported.
-		// The longest assignable prefix is /64, making it max 256 subnets per block.
-		// The following logic will hold as long as /64 is the smallest assignable subnet size.
+		// The quantity of IPv6 blocks is the number of /64 subnets, the
+		// longest assignable prefix. The /56 block allocated with the first
+		// device in a facility has 256 of them. The following logic will
+		// hold as long as /64 is the smallest assignable subnet size.
 		bits := 64 - reservedBlock.CIDR
 		if bits > 30 {
 			return fmt.Errorf("Strange (too small) CIDR prefix: %d", reservedBlock.CIDR)
@@ -146,18 +205,41 @@ func resourcePacketReservedIPBlockRead(d *schema.ResourceData, meta interface{})
 	client := meta.(*packngo.Client)
 	id := d.Id()
 
-	reservedBlock, _, err := client.ProjectIPs.Get(id)
+	reservedBlock, err := getIPReservation(client, id)
 	if err != nil {
-		return fmt.Errorf("Error reading IP address block with ID %s: %s", id, err)
+		err = friendlyError(err)
+
+		// If the block is somehow already removed, mark as succesfully gone.
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
 	}
-	err = loadBlock(d, reservedBlock)
+	err = loadBlock(d, &reservedBlock.IPAddressReservation)
 	if err != nil {
 		return err
 	}
+	d.Set("type", reservedBlock.reservationType())
 
 	return nil
 }
 
+// reservationType maps the flags of a reservation to the type used when
+// requesting it.
+func (r *packetIPReservation) reservationType() string {
+	switch {
+	case r.AddressFamily == 6:
+		return "public_ipv6"
+	case !r.Public:
+		return "private_ipv4"
+	case r.Global:
+		return "global_ipv4"
+	}
+	return "public_ipv4"
+}
+
 func resourcePacketReservedIPBlockDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
@@ -166,9 +248,28 @@ func resourcePacketReservedIPBlockDelete(d *schema.ResourceData, meta interface{
 	_, err := client.ProjectIPs.Remove(id)
 
 	if err != nil {
-		return fmt.Errorf("Error deleting IP reservation block %s: %s", id, err)
+		err = friendlyError(err)
+		if !isNotFound(err) {
+			return err
+		}
 	}
 
 	d.SetId("")
 	return nil
 }
+
+func intPowerOfTwoBetween(min, max int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warns []string, errs []error) {
+		v, ok := i.(int)
+		if !ok {
+			errs = append(errs, fmt.Errorf("expected type of %s to be int", k))
+			return
+		}
+
+		if v < min || v > max || v&(v-1) != 0 {
+			errs = append(errs, fmt.Errorf("expected %s to be a power of 2 between %d and %d, got %d", k, min, max, v))
+		}
+
+		return
+	}
+}
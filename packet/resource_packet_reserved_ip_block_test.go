@@ -38,6 +38,56 @@ func TestAccPacketReservedIPBlockBasic(t *testing.T) {
 	})
 }
 
+func TestAccPacketReservedIPBlockPrivate(t *testing.T) {
+
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketReservedIPBlockDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketReservedIPBlockConfigPrivate(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_reserved_ip_block.test", "type", "private_ipv4"),
+					resource.TestCheckResourceAttr(
+						"packet_reserved_ip_block.test", "public", "false"),
+					resource.TestCheckResourceAttr(
+						"packet_reserved_ip_block.test", "quantity", "4"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPacketReservedIPBlockGlobal(t *testing.T) {
+
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketReservedIPBlockDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketReservedIPBlockConfigGlobal(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_reserved_ip_block.test", "type", "global_ipv4"),
+					resource.TestCheckResourceAttr(
+						"packet_reserved_ip_block.test", "facility", ""),
+					resource.TestCheckResourceAttr(
+						"packet_reserved_ip_block.test", "quantity", "1"),
+					resource.TestCheckResourceAttr(
+						"packet_reserved_ip_block.test", "netmask", "255.255.255.255"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccPacketReservedIPBlock_importBasic(t *testing.T) {
 
 	rs := acctest.RandString(10)
@@ -86,3 +136,31 @@ resource "packet_reserved_ip_block" "test" {
 	quantity = 2
 }`, name)
 }
+
+func testAccCheckPacketReservedIPBlockConfigPrivate(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "foobar" {
+    name = "%s"
+}
+
+resource "packet_reserved_ip_block" "test" {
+    project_id = "${packet_project.foobar.id}"
+    facility = "ewr1"
+    type = "private_ipv4"
+    quantity = 4
+    comments = "terraform acceptance test"
+}`, name)
+}
+
+func testAccCheckPacketReservedIPBlockConfigGlobal(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "foobar" {
+    name = "%s"
+}
+
+resource "packet_reserved_ip_block" "test" {
+    project_id = "${packet_project.foobar.id}"
+    type = "global_ipv4"
+    quantity = 1
+}`, name)
+}
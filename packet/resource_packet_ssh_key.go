@@ -90,13 +90,14 @@ func resourcePacketSSHKeyRead(d *schema.ResourceData, meta interface{}) error {
 func resourcePacketSSHKeyUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
+	label := d.Get("name").(string)
+	key := d.Get("public_key").(string)
 	updateRequest := &packngo.SSHKeyUpdateRequest{
-		ID:    d.Get("id").(string),
-		Label: d.Get("name").(string),
-		Key:   d.Get("public_key").(string),
+		Label: &label,
+		Key:   &key,
 	}
 
-	_, _, err := client.SSHKeys.Update(updateRequest)
+	_, _, err := client.SSHKeys.Update(d.Id(), updateRequest)
 	if err != nil {
 		return friendlyError(err)
 	}
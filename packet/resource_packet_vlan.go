@@ -0,0 +1,112 @@
+package packet
+
+import (
+	"path"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/packethost/packngo"
+)
+
+// virtualNetwork adds the project, which the VLAN is assigned to, to
+// packngo.VirtualNetwork.
+type virtualNetwork struct {
+	packngo.VirtualNetwork
+	Project packngo.Href `json:"assigned_to,omitempty"`
+}
+
+func resourcePacketVlan() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePacketVlanCreate,
+		Read:   resourcePacketVlanRead,
+		Delete: resourcePacketVlanDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"facility": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"vxlan": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePacketVlanCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+	projectID := d.Get("project_id").(string)
+
+	createRequest := &packngo.VirtualNetworkCreateRequest{
+		ProjectID:   projectID,
+		Facility:    d.Get("facility").(string),
+		Description: d.Get("description").(string),
+	}
+
+	vlan, _, err := client.ProjectVirtualNetworks.Create(createRequest)
+	if err != nil {
+		return friendlyError(err)
+	}
+
+	d.SetId(vlan.ID)
+
+	return resourcePacketVlanRead(d, meta)
+}
+
+func resourcePacketVlanRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	vlan, err := getVirtualNetwork(client, d.Id())
+	if err != nil {
+		err = friendlyError(err)
+
+		// If the VLAN somehow already destroyed, mark as succesfully gone.
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("description", vlan.Description)
+	d.Set("vxlan", vlan.VXLAN)
+	d.Set("facility", vlan.FacilityCode)
+	if vlan.Project.Href != "" {
+		d.Set("project_id", path.Base(vlan.Project.Href))
+	}
+
+	return nil
+}
+
+func resourcePacketVlanDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*packngo.Client)
+
+	_, err := client.ProjectVirtualNetworks.Delete(d.Id())
+	if err != nil {
+		err = friendlyError(err)
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
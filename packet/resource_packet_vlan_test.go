@@ -0,0 +1,67 @@
+package packet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/packethost/packngo"
+)
+
+func TestAccPacketVlanBasic(t *testing.T) {
+	rs := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPacketVlanDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckPacketVlanConfigBasic(rs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"packet_vlan.test", "facility", "ewr1"),
+					resource.TestCheckResourceAttr(
+						"packet_vlan.test", "description", "tftest"),
+					resource.TestCheckResourceAttrSet(
+						"packet_vlan.test", "vxlan"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "packet_vlan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPacketVlanDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*packngo.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "packet_vlan" {
+			continue
+		}
+		if _, err := getVirtualNetwork(client, rs.Primary.ID); err == nil {
+			return fmt.Errorf("VLAN still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPacketVlanConfigBasic(name string) string {
+	return fmt.Sprintf(`
+resource "packet_project" "test" {
+    name = "%s"
+}
+
+resource "packet_vlan" "test" {
+    project_id  = "${packet_project.test.id}"
+    facility    = "ewr1"
+    description = "tftest"
+}`, name)
+}
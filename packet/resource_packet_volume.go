@@ -247,19 +247,19 @@ func resourcePacketVolumeRead(d *schema.ResourceData, meta interface{}) error {
 func resourcePacketVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 
-	updateRequest := &packngo.VolumeUpdateRequest{
-		ID: d.Get("id").(string),
-	}
+	updateRequest := &packngo.VolumeUpdateRequest{}
 
 	if attr, ok := d.GetOk("description"); ok {
-		updateRequest.Description = attr.(string)
+		description := attr.(string)
+		updateRequest.Description = &description
 	}
 
 	if attr, ok := d.GetOk("plan"); ok {
-		updateRequest.Plan = attr.(string)
+		plan := attr.(string)
+		updateRequest.PlanID = &plan
 	}
 
-	_, _, err := client.Volumes.Update(updateRequest)
+	_, _, err := client.Volumes.Update(d.Id(), updateRequest)
 	if err != nil {
 		return friendlyError(err)
 	}
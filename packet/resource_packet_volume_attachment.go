@@ -1,13 +1,32 @@
 package packet
 
 import (
+	"fmt"
 	"log"
+	"path"
 	"path/filepath"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/packethost/packngo"
 )
 
+// volumeDevicePathPrefix is where the packet-block-storage-attach script
+// maps attached volumes in the guest OS.
+const volumeDevicePathPrefix = "/dev/mapper/"
+
+// volumeAccess holds the iSCSI details of a volume.
+type volumeAccess struct {
+	IQN string   `json:"iqn"`
+	IPs []string `json:"ips"`
+}
+
+type volumeWithAccess struct {
+	packngo.Volume
+	Access *volumeAccess `json:"access,omitempty"`
+}
+
 func resourcePacketVolumeAttachment() *schema.Resource {
 	return &schema.Resource{
 		Create: resourcePacketVolumeAttachmentCreate,
@@ -29,6 +48,32 @@ func resourcePacketVolumeAttachment() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+
+			"href": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"volume_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"device_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"iqn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"iscsi_ips": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -40,31 +85,87 @@ func resourcePacketVolumeAttachmentCreate(d *schema.ResourceData, meta interface
 	log.Printf("[DEBUG] Attaching Volume (%s) to Instance (%s)\n", vID, dID)
 	va, _, err := client.VolumeAttachments.Create(vID, dID)
 	if err != nil {
-		switch err.(type) {
-		case *packngo.ErrorResponse:
-			e := err.(*packngo.ErrorResponse)
-			if len(e.Errors) == 1 {
-				if e.Errors[0] == "Instance is already attached to this volume" {
-					log.Printf("[DEBUG] Volume (%s) is already attached to Instance (%s)", vID, dID)
-					break
-				}
-			}
+		if !isAlreadyAttached(err) {
+			return friendlyError(err)
+		}
+
+		log.Printf("[DEBUG] Volume (%s) is already attached to Instance (%s)", vID, dID)
+		va, err = findVolumeAttachment(client, vID, dID)
+		if err != nil {
+			return err
 		}
-		return err
 	}
 
 	d.SetId(va.ID)
 	return resourcePacketVolumeAttachmentRead(d, meta)
 }
 
+func isAlreadyAttached(err error) bool {
+	if e, ok := err.(*packngo.ErrorResponse); ok {
+		return len(e.Errors) == 1 && e.Errors[0] == "Instance is already attached to this volume"
+	}
+	return false
+}
+
+// findVolumeAttachment looks up an existing attachment of a volume to a device,
+// so that it can be adopted to the state.
+func findVolumeAttachment(client *packngo.Client, volumeID, deviceID string) (*packngo.VolumeAttachment, error) {
+	volume, _, err := client.Volumes.Get(volumeID)
+	if err != nil {
+		return nil, friendlyError(err)
+	}
+
+	for _, a := range volume.Attachments {
+		if path.Base(a.Device.Href) == deviceID || a.Device.ID == deviceID {
+			if a.ID == "" {
+				a.ID = path.Base(a.Href)
+			}
+			return a, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Volume %s is reported as attached to device %s, but the attachment was not found", volumeID, deviceID)
+}
+
 func resourcePacketVolumeAttachmentRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*packngo.Client)
 	va, _, err := client.VolumeAttachments.Get(d.Id())
 	if err != nil {
+		err = friendlyError(err)
+
+		// If the volume was detached out of band, mark as succesfully gone.
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
 		return err
 	}
+	vID := filepath.Base(va.Volume.Href)
 	d.Set("device_id", filepath.Base(va.Device.Href))
-	d.Set("volume_id", filepath.Base(va.Volume.Href))
+	d.Set("volume_id", vID)
+	d.Set("href", va.Href)
+
+	volume, err := getVolumeWithAccess(client, vID)
+	if err != nil {
+		// If the volume was deleted out of band, the attachment is gone too.
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+	d.Set("volume_name", volume.Name)
+	d.Set("device_path", volumeDevicePathPrefix+volume.Name)
+
+	iscsiIPs := []string{}
+	if volume.Access != nil {
+		d.Set("iqn", volume.Access.IQN)
+		iscsiIPs = append(iscsiIPs, volume.Access.IPs...)
+	}
+	d.Set("iscsi_ips", iscsiIPs)
+
 	return nil
 }
 
@@ -72,7 +173,50 @@ func resourcePacketVolumeAttachmentDelete(d *schema.ResourceData, meta interface
 	client := meta.(*packngo.Client)
 	_, err := client.VolumeAttachments.Delete(d.Id())
 	if err != nil {
+		err = friendlyError(err)
+
+		// If the attachment is already gone, there's nothing to wait for.
+		if isNotFound(err) {
+			return nil
+		}
+
 		return err
 	}
-	return nil
+
+	_, err = waitForVolumeDetach(d.Get("volume_id").(string), d.Id(), meta)
+	return err
+}
+
+// waitForVolumeDetach waits until the attachment disappears from the list of
+// the volume's attachments. Detaching is asynchronous, and destroying
+// the device or the volume while it's in progress fails.
+func waitForVolumeDetach(volumeID, attachmentID string, meta interface{}) (interface{}, error) {
+	client := meta.(*packngo.Client)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"attached"},
+		Target:  []string{"detached"},
+		Refresh: func() (interface{}, string, error) {
+			volume, _, err := client.Volumes.Get(volumeID)
+			if err != nil {
+				err = friendlyError(err)
+				// StateChangeConf treats a nil result as not found yet,
+				// so the volume ID is returned instead.
+				if isNotFound(err) {
+					return volumeID, "detached", nil
+				}
+				return nil, "", err
+			}
+			for _, a := range volume.Attachments {
+				if a.ID == attachmentID || path.Base(a.Href) == attachmentID {
+					return volume, "attached", nil
+				}
+			}
+			return volume, "detached", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      3 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	return stateConf.WaitForState()
 }
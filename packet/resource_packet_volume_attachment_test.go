@@ -2,6 +2,7 @@ package packet
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -27,6 +28,11 @@ func TestAccPacketVolumeAttachmentBasic(t *testing.T) {
 					resource.TestCheckResourceAttrPair(
 						"packet_volume_attachment.test", "device_id",
 						"packet_device.test", "id"),
+					resource.TestCheckResourceAttrSet(
+						"packet_volume_attachment.test", "href"),
+					resource.TestMatchResourceAttr(
+						"packet_volume_attachment.test", "device_path",
+						regexp.MustCompile("^/dev/mapper/volume-")),
 				),
 			},
 			resource.TestStep{